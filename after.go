@@ -41,3 +41,40 @@ func AfterFunc[T any](seq iter.Seq[T], pred Predicate[T]) iter.Seq[T] {
 		}
 	}
 }
+
+// After2 is the keyed companion to After; it skips the first n pairs of
+// seq2 and yields the rest.
+func After2[K, V any](seq2 iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		skipped := 0
+		for k, v := range seq2 {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// AfterFunc2 is the keyed companion to AfterFunc; it drops pairs from seq2
+// while pred reports true and yields the first pair for which it returns
+// false along with everything after it.
+func AfterFunc2[K, V any](seq2 iter.Seq2[K, V], pred Predicate2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		skipping := true
+		for k, v := range seq2 {
+			if skipping {
+				if pred(k, v) {
+					continue
+				}
+				skipping = false
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
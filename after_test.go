@@ -120,3 +120,39 @@ func TestAfterFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+type after2TableTest[K, V comparable] struct {
+	name     string
+	input    []iters.Pair[K, V]
+	n        int
+	pred     func(K, V) bool
+	expected []iters.Pair[K, V]
+}
+
+func (test after2TableTest[K, V]) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		input := iters.Unpairs(slices.Values(test.input))
+		if test.pred != nil {
+			if got := slices.Collect(iters.Pairs(iters.AfterFunc2(input, test.pred))); !slices.Equal(got, test.expected) {
+				t.Fatalf("AfterFunc2: expected %v, got %v", test.expected, got)
+			}
+			return
+		}
+		if got := slices.Collect(iters.Pairs(iters.After2(input, test.n))); !slices.Equal(got, test.expected) {
+			t.Fatalf("After2: expected %v, got %v", test.expected, got)
+		}
+	})
+}
+
+func TestAfter2(t *testing.T) {
+	pairs := []iters.Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 1}}
+	tests := []runnableTest{
+		after2TableTest[string, int]{name: "skip one", input: pairs, n: 1, expected: pairs[1:]},
+		after2TableTest[string, int]{name: "skip all", input: pairs, n: 5, expected: nil},
+		after2TableTest[string, int]{name: "while small", input: pairs, pred: func(_ string, v int) bool { return v < 2 }, expected: pairs[1:]},
+		after2TableTest[string, int]{name: "always matches", input: pairs, pred: func(string, int) bool { return true }, expected: nil},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
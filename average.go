@@ -46,3 +46,27 @@ func AverageFunc[T any](seq iter.Seq[T], fn func(T) float64) float64 {
 	}
 	return sum / float64(count)
 }
+
+// Average2 returns the arithmetic mean of the values of seq2. It returns 0
+// when seq2 is empty.
+func Average2[K any, V Number](seq2 iter.Seq2[K, V]) float64 {
+	return Average(Values(seq2))
+}
+
+// AverageFunc2 computes the arithmetic mean of fn(k, v) for every pair in
+// seq2. It returns 0 when seq2 is empty.
+func AverageFunc2[K, V any](seq2 iter.Seq2[K, V], fn func(K, V) float64) float64 {
+	var (
+		sum   float64
+		count int
+	)
+	for k, v := range seq2 {
+		sum += fn(k, v)
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
@@ -103,3 +103,18 @@ func TestAverageFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestAverage2(t *testing.T) {
+	input := slices.All([]float64{1, 2, 6})
+
+	if got := iters.Average2(input); got != 3 {
+		t.Fatalf("Average2: expected 3, got %v", got)
+	}
+	weighted := func(i int, v float64) float64 { return float64(i) * v }
+	if got := iters.AverageFunc2(input, weighted); got != 14.0/3 {
+		t.Fatalf("AverageFunc2: expected %v, got %v", 14.0/3, got)
+	}
+	if got := iters.Average2(slices.All([]int(nil))); got != 0 {
+		t.Fatalf("Average2: expected 0 for an empty sequence, got %v", got)
+	}
+}
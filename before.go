@@ -34,3 +34,24 @@ func BeforeFunc[T any](seq iter.Seq[T], pred Predicate[T]) iter.Seq[T] {
 		}
 	}
 }
+
+// Before2 is the keyed companion to Before; it yields at most n pairs from
+// seq2.
+func Before2[K, V any](seq2 iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return Limit2(seq2, n)
+}
+
+// BeforeFunc2 is the keyed companion to BeforeFunc; it yields pairs from
+// seq2 until pred first returns true, excluding the matching pair.
+func BeforeFunc2[K, V any](seq2 iter.Seq2[K, V], pred Predicate2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq2 {
+			if pred(k, v) {
+				return
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
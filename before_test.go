@@ -114,3 +114,40 @@ func TestBeforeFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+type before2TableTest[K, V comparable] struct {
+	name     string
+	input    []iters.Pair[K, V]
+	n        int
+	pred     func(K, V) bool
+	expected []iters.Pair[K, V]
+}
+
+func (test before2TableTest[K, V]) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		input := iters.Unpairs(slices.Values(test.input))
+		if test.pred != nil {
+			if got := slices.Collect(iters.Pairs(iters.BeforeFunc2(input, test.pred))); !slices.Equal(got, test.expected) {
+				t.Fatalf("BeforeFunc2: expected %v, got %v", test.expected, got)
+			}
+			return
+		}
+		if got := slices.Collect(iters.Pairs(iters.Before2(input, test.n))); !slices.Equal(got, test.expected) {
+			t.Fatalf("Before2: expected %v, got %v", test.expected, got)
+		}
+	})
+}
+
+func TestBefore2(t *testing.T) {
+	pairs := []iters.Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}}
+	tests := []runnableTest{
+		before2TableTest[string, int]{name: "first two", input: pairs, n: 2, expected: pairs[:2]},
+		before2TableTest[string, int]{name: "n larger than len", input: pairs, n: 5, expected: pairs},
+		before2TableTest[string, int]{name: "zero", input: pairs, n: 0, expected: nil},
+		before2TableTest[string, int]{name: "until key", input: pairs, pred: func(k string, _ int) bool { return k == "c" }, expected: pairs[:2]},
+		before2TableTest[string, int]{name: "never matches", input: pairs, pred: func(string, int) bool { return false }, expected: pairs},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
@@ -0,0 +1,245 @@
+package iters
+
+import (
+	"iter"
+	"slices"
+)
+
+// CombinatoricsOptions configures Permutations, Combinations,
+// CombinationsWithReplacement, PowerSet and Product. A nil
+// *CombinatoricsOptions yields a freshly allocated slice for every
+// arrangement.
+type CombinatoricsOptions struct {
+	// Reuse yields the same slice for every arrangement, overwriting it
+	// before each yield. This avoids an allocation per arrangement, but
+	// consumers must copy a slice to keep it past the current iteration.
+	Reuse bool
+}
+
+// arrangement builds the slices yielded by the combinatoric sequences from
+// the indices of the chosen items.
+type arrangement[T any] struct {
+	items []T
+	buf   []T
+	reuse bool
+}
+
+func newArrangement[T any](items []T, k int, opts *CombinatoricsOptions) *arrangement[T] {
+	a := &arrangement[T]{items: items}
+	if opts != nil && opts.Reuse {
+		a.reuse = true
+		a.buf = make([]T, 0, k)
+	}
+	return a
+}
+
+func (a *arrangement[T]) build(indices []int) []T {
+	out := a.buf[:0]
+	if !a.reuse {
+		out = make([]T, 0, len(indices))
+	}
+	for _, i := range indices {
+		out = append(out, a.items[i])
+	}
+	if a.reuse {
+		a.buf = out
+	}
+	return out
+}
+
+// Permutations returns a sequence of every ordered arrangement of k
+// distinct positions of items, in lexicographic order of the positions, so
+// sorted items produce sorted permutations. Items are told apart by
+// position, not value. When k is negative or greater than len(items)
+// nothing is produced; when k is zero a single empty slice is produced.
+// Arrangements are computed one at a time as the consumer asks for them.
+func Permutations[T any](items []T, k int, opts *CombinatoricsOptions) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		if k < 0 || k > n {
+			return
+		}
+		a := newArrangement(items, k, opts)
+
+		// This is the cycle-based algorithm of Python's
+		// itertools.permutations: indices holds the current arrangement in
+		// its first k entries, and cycles counts the remaining choices for
+		// each position.
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		cycles := make([]int, k)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+
+		if !yield(a.build(indices[:k])) {
+			return
+		}
+		for {
+			i := k - 1
+			for ; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] > 0 {
+					j := n - cycles[i]
+					indices[i], indices[j] = indices[j], indices[i]
+					break
+				}
+				// Rotate position i to the end and restart its cycle.
+				first := indices[i]
+				copy(indices[i:], indices[i+1:])
+				indices[n-1] = first
+				cycles[i] = n - i
+			}
+			if i < 0 {
+				return
+			}
+			if !yield(a.build(indices[:k])) {
+				return
+			}
+		}
+	}
+}
+
+// Combinations returns a sequence of every selection of k distinct
+// positions of items, each yielded in the order of items, with selections
+// in lexicographic order. When k is negative or greater than len(items)
+// nothing is produced; when k is zero a single empty slice is produced.
+func Combinations[T any](items []T, k int, opts *CombinatoricsOptions) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		if k < 0 || k > n {
+			return
+		}
+		a := newArrangement(items, k, opts)
+
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			if !yield(a.build(indices)) {
+				return
+			}
+			// Advance the rightmost index that has room to move, then pack
+			// the indices after it directly behind it.
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+// CombinationsWithReplacement is like Combinations, but a position of
+// items may be selected more than once. When k is negative, or items is
+// empty and k is positive, nothing is produced.
+func CombinationsWithReplacement[T any](items []T, k int, opts *CombinatoricsOptions) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		if k < 0 || n == 0 && k > 0 {
+			return
+		}
+		a := newArrangement(items, k, opts)
+
+		indices := make([]int, k)
+		for {
+			if !yield(a.build(indices)) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[i]
+			}
+		}
+	}
+}
+
+// PowerSet returns a sequence of every subset of items, each yielded in the
+// order of items, in lexicographic order starting with the empty set. For
+// items a, b and c that is [], [a], [a b], [a b c], [a c], [b], [b c], [c].
+func PowerSet[T any](items []T, opts *CombinatoricsOptions) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		a := newArrangement(items, n, opts)
+
+		indices := make([]int, 0, n)
+		for {
+			if !yield(a.build(indices)) {
+				return
+			}
+			switch last := len(indices) - 1; {
+			case last < 0:
+				if n == 0 {
+					return
+				}
+				indices = append(indices, 0)
+			case indices[last] < n-1:
+				indices = append(indices, indices[last]+1)
+			default:
+				indices = indices[:last]
+				if last == 0 {
+					return
+				}
+				indices[last-1]++
+			}
+		}
+	}
+}
+
+// Product returns the Cartesian product of seqs: every slice holding one
+// element of each sequence, in order, with the last sequence varying
+// fastest. Each of seqs is read once, when iteration starts, so they must
+// be finite. When any sequence is empty nothing is produced; with no
+// sequences a single empty slice is produced.
+func Product[T any](opts *CombinatoricsOptions, seqs ...iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		pools := make([][]T, len(seqs))
+		for i, seq := range seqs {
+			pools[i] = slices.Collect(seq)
+			if len(pools[i]) == 0 {
+				return
+			}
+		}
+
+		reuse := opts != nil && opts.Reuse
+		indices := make([]int, len(pools))
+		buf := make([]T, len(pools))
+		for {
+			out := buf
+			if !reuse {
+				out = make([]T, len(pools))
+			}
+			for i, pool := range pools {
+				out[i] = pool[indices[i]]
+			}
+			if !yield(out) {
+				return
+			}
+			i := len(pools) - 1
+			for i >= 0 && indices[i] == len(pools[i])-1 {
+				indices[i] = 0
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+		}
+	}
+}
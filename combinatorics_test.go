@@ -0,0 +1,220 @@
+package iters_test
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func ExamplePermutations() {
+	for p := range iters.Permutations([]string{"a", "b", "c"}, 2, nil) {
+		fmt.Print(p, " ")
+	}
+	fmt.Println()
+	// Output:
+	// [a b] [a c] [b a] [b c] [c a] [c b]
+}
+
+func ExampleCombinations() {
+	for c := range iters.Combinations([]int{1, 2, 3, 4}, 2, nil) {
+		fmt.Print(c, " ")
+	}
+	fmt.Println()
+	// Output:
+	// [1 2] [1 3] [1 4] [2 3] [2 4] [3 4]
+}
+
+func ExampleProduct() {
+	sizes := slices.Values([]string{"small", "large"})
+	colors := slices.Values([]string{"red", "blue"})
+	for combo := range iters.Product(nil, sizes, colors) {
+		fmt.Println(combo)
+	}
+	// Output:
+	// [small red]
+	// [small blue]
+	// [large red]
+	// [large blue]
+}
+
+func ExamplePowerSet() {
+	fmt.Println(slices.Collect(iters.PowerSet([]string{"a", "b", "c"}, nil)))
+	// Output:
+	// [[] [a] [a b] [a b c] [a c] [b] [b c] [c]]
+}
+
+// referenceArrangements builds every arrangement of k positions out of n
+// recursively, choosing positions with allowed, in lexicographic order.
+func referenceArrangements(n, k int, allowed func(prefix []int, i int) bool) [][]int {
+	var (
+		out    [][]int
+		prefix []int
+		walk   func()
+	)
+	walk = func() {
+		if len(prefix) == k {
+			out = append(out, slices.Clone(prefix))
+			return
+		}
+		for i := range n {
+			if allowed(prefix, i) {
+				prefix = append(prefix, i)
+				walk()
+				prefix = prefix[:len(prefix)-1]
+			}
+		}
+	}
+	walk()
+	return out
+}
+
+type combinatoricsTableTest struct {
+	name      string
+	generate  func(items []int, k int, opts *iters.CombinatoricsOptions) iter.Seq[[]int]
+	reference func(n, k int) [][]int
+}
+
+func (test combinatoricsTableTest) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		for n := range 6 {
+			items := slices.Collect(iters.Range(0, n, 1))
+			for k := -1; k <= n+1; k++ {
+				want := [][]int(nil)
+				if k >= 0 {
+					want = test.reference(n, k)
+				}
+				got := slices.Collect(test.generate(items, k, nil))
+				if !slices.EqualFunc(got, want, slices.Equal) {
+					t.Fatalf("%s(%d, %d): expected %v, got %v", test.name, n, k, want, got)
+				}
+				if !slices.IsSortedFunc(got, slices.Compare) {
+					t.Fatalf("%s(%d, %d): not in lexicographic order: %v", test.name, n, k, got)
+				}
+
+				var reused [][]int
+				for s := range test.generate(items, k, &iters.CombinatoricsOptions{Reuse: true}) {
+					reused = append(reused, slices.Clone(s))
+				}
+				if !slices.EqualFunc(reused, want, slices.Equal) {
+					t.Fatalf("%s(%d, %d) with Reuse: expected %v, got %v", test.name, n, k, want, reused)
+				}
+			}
+		}
+	})
+}
+
+func TestCombinatorics(t *testing.T) {
+	tests := []runnableTest{
+		combinatoricsTableTest{
+			name: "Permutations",
+			generate: func(items []int, k int, opts *iters.CombinatoricsOptions) iter.Seq[[]int] {
+				return iters.Permutations(items, k, opts)
+			},
+			reference: func(n, k int) [][]int {
+				return referenceArrangements(n, k, func(prefix []int, i int) bool { return !slices.Contains(prefix, i) })
+			},
+		},
+		combinatoricsTableTest{
+			name: "Combinations",
+			generate: func(items []int, k int, opts *iters.CombinatoricsOptions) iter.Seq[[]int] {
+				return iters.Combinations(items, k, opts)
+			},
+			reference: func(n, k int) [][]int {
+				return referenceArrangements(n, k, func(prefix []int, i int) bool { return len(prefix) == 0 || i > prefix[len(prefix)-1] })
+			},
+		},
+		combinatoricsTableTest{
+			name: "CombinationsWithReplacement",
+			generate: func(items []int, k int, opts *iters.CombinatoricsOptions) iter.Seq[[]int] {
+				return iters.CombinationsWithReplacement(items, k, opts)
+			},
+			reference: func(n, k int) [][]int {
+				return referenceArrangements(n, k, func(prefix []int, i int) bool { return len(prefix) == 0 || i >= prefix[len(prefix)-1] })
+			},
+		},
+		combinatoricsTableTest{
+			name: "Product",
+			generate: func(items []int, k int, opts *iters.CombinatoricsOptions) iter.Seq[[]int] {
+				seqs := make([]iter.Seq[int], max(k, 0))
+				for i := range seqs {
+					seqs[i] = slices.Values(items)
+				}
+				if k < 0 {
+					return func(func([]int) bool) {}
+				}
+				return iters.Product(opts, seqs...)
+			},
+			reference: func(n, k int) [][]int {
+				return referenceArrangements(n, k, func([]int, int) bool { return true })
+			},
+		},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	for n := range 8 {
+		items := slices.Collect(iters.Range(0, n, 1))
+		got := slices.Collect(iters.PowerSet(items, nil))
+		if len(got) != 1<<n || !slices.IsSortedFunc(got, slices.Compare) {
+			t.Fatalf("PowerSet(%d): expected %d subsets in order, got %v", n, 1<<n, got)
+		}
+		seen := make(map[string]bool)
+		for _, s := range got {
+			if !slices.IsSorted(s) || seen[fmt.Sprint(s)] {
+				t.Fatalf("PowerSet(%d): unexpected subset %v", n, s)
+			}
+			seen[fmt.Sprint(s)] = true
+		}
+	}
+}
+
+func TestCombinatoricsReuse(t *testing.T) {
+	var first []int
+	for p := range iters.Permutations([]int{1, 2, 3}, 3, &iters.CombinatoricsOptions{Reuse: true}) {
+		if first == nil {
+			first = p
+			continue
+		}
+		if &p[0] != &first[0] {
+			t.Fatalf("Permutations: expected the slice to be reused")
+		}
+	}
+
+	fresh := slices.Collect(iters.Combinations([]int{1, 2, 3}, 2, nil))
+	fresh[0][0] = 99
+	if fresh[1][0] != 1 {
+		t.Fatalf("Combinations: expected fresh slices, got %v", fresh)
+	}
+}
+
+func TestCombinatoricsStopEarly(t *testing.T) {
+	items := slices.Collect(iters.Range(0, 20, 1))
+
+	// 20! permutations and 2^20 subsets would never finish if computed
+	// eagerly.
+	if got := slices.Collect(iters.Limit(iters.Permutations(items, 20, nil), 3)); len(got) != 3 {
+		t.Fatalf("Permutations: expected 3, got %d", len(got))
+	}
+	if got := slices.Collect(iters.Limit(iters.PowerSet(items, nil), 3)); len(got) != 3 {
+		t.Fatalf("PowerSet: expected 3, got %d", len(got))
+	}
+
+	src, c := iterstest.Counting(slices.Values(items))
+	if got := slices.Collect(iters.Limit(iters.Product(nil, src, src, src), 2)); len(got) != 2 {
+		t.Fatalf("Product: expected 2, got %d", len(got))
+	}
+	if c.Iterations() != 3 {
+		t.Fatalf("Product: expected each input to be read once, got %d iterations", c.Iterations())
+	}
+
+	iterstest.CheckSeq(t, iters.Permutations(items[:4], 3, nil))
+	iterstest.CheckSeq(t, iters.Combinations(items[:6], 3, nil))
+	iterstest.CheckSeq(t, iters.PowerSet(items[:4], nil))
+}
@@ -67,3 +67,42 @@ func CompareFunc[T1, T2 any](s1 iter.Seq[T1], s2 iter.Seq[T2], cmp func(T1, T2)
 		}
 	}
 }
+
+// Compare2 is the keyed companion to Compare. Pairs are compared by key and
+// then by value, until a difference is found or one sequence ends.
+func Compare2[K, V cmp.Ordered](s1, s2 iter.Seq2[K, V]) int {
+	return CompareFunc2(s1, s2, func(k1 K, v1 V, k2 K, v2 V) int {
+		return cmp.Or(cmp.Compare(k1, k2), cmp.Compare(v1, v2))
+	})
+}
+
+// CompareFunc2 behaves like Compare2 but calls cmp for each pair of pairs.
+// The first non-zero result is returned, otherwise the shorter sequence
+// sorts before the longer one.
+func CompareFunc2[K1, V1, K2, V2 any](s1 iter.Seq2[K1, V1], s2 iter.Seq2[K2, V2], cmp func(K1, V1, K2, V2) int) int {
+	it1, stop1 := iter.Pull2(s1)
+	it2, stop2 := iter.Pull2(s2)
+	defer stop1()
+	defer stop2()
+
+	for {
+		var (
+			k1, v1, ok1 = it1()
+			k2, v2, ok2 = it2()
+		)
+
+		if !ok1 && !ok2 {
+			return 0 // both sequences are exhausted and equal
+		}
+		if !ok1 {
+			return -1 // s1 is exhausted but s2 is not, so s1 < s2
+		}
+		if !ok2 {
+			return 1 // s2 is exhausted but s1 is not, so s1 > s2
+		}
+
+		if cmpResult := cmp(k1, v1, k2, v2); cmpResult != 0 {
+			return cmpResult
+		}
+	}
+}
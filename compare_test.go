@@ -3,6 +3,7 @@ package iters_test
 import (
 	"cmp"
 	"fmt"
+	"iter"
 	"slices"
 	"strings"
 	"testing"
@@ -129,3 +130,30 @@ func TestCompareFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestCompare2(t *testing.T) {
+	pairs := func(ps ...iters.Pair[string, int]) iter.Seq2[string, int] {
+		return iters.Unpairs(slices.Values(ps))
+	}
+
+	tests := []struct {
+		name     string
+		s1, s2   iter.Seq2[string, int]
+		expected int
+	}{
+		{"equal", pairs(iters.Pair[string, int]{"a", 1}), pairs(iters.Pair[string, int]{"a", 1}), 0},
+		{"key differs", pairs(iters.Pair[string, int]{"a", 9}), pairs(iters.Pair[string, int]{"b", 1}), -1},
+		{"value differs", pairs(iters.Pair[string, int]{"a", 2}), pairs(iters.Pair[string, int]{"a", 1}), 1},
+		{"shorter first", pairs(), pairs(iters.Pair[string, int]{"a", 1}), -1},
+	}
+	for _, test := range tests {
+		if got := iters.Compare2(test.s1, test.s2); got != test.expected {
+			t.Fatalf("Compare2 %s: expected %d, got %d", test.name, test.expected, got)
+		}
+	}
+
+	byValue := func(_ string, a int, _ string, b int) int { return a - b }
+	if got := iters.CompareFunc2(pairs(iters.Pair[string, int]{"z", 1}), pairs(iters.Pair[string, int]{"a", 2}), byValue); got >= 0 {
+		t.Fatalf("CompareFunc2: expected a negative result, got %d", got)
+	}
+}
@@ -41,3 +41,42 @@ func ContainsFunc2[K, V any](seq2 iter.Seq2[K, V], fn Predicate2[K, V]) bool {
 	}
 	return false
 }
+
+// ContainsAll reports whether every one of values appears in seq. It reads
+// seq once and stops as soon as the last of them is found. With no values
+// it returns true without reading seq.
+func ContainsAll[V comparable](seq iter.Seq[V], values ...V) bool {
+	missing := make(map[V]struct{}, len(values))
+	for _, v := range values {
+		missing[v] = struct{}{}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+	for item := range seq {
+		delete(missing, item)
+		if len(missing) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAny reports whether any of values appears in seq. It reads seq
+// once and stops at the first match. With no values it returns false
+// without reading seq.
+func ContainsAny[V comparable](seq iter.Seq[V], values ...V) bool {
+	if len(values) == 0 {
+		return false
+	}
+	wanted := make(map[V]struct{}, len(values))
+	for _, v := range values {
+		wanted[v] = struct{}{}
+	}
+	for item := range seq {
+		if _, ok := wanted[item]; ok {
+			return true
+		}
+	}
+	return false
+}
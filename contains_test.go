@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
 )
 
 func ExampleContains_numbers() {
@@ -385,3 +386,53 @@ func TestContainsFunc2(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func ExampleContainsAll() {
+	tags := slices.Values([]string{"go", "iter", "generics", "stream"})
+	fmt.Println(iters.ContainsAll(tags, "go", "stream"))
+	fmt.Println(iters.ContainsAny(tags, "rust", "zig"))
+	// Output:
+	// true
+	// false
+}
+
+func TestContainsAllAny(t *testing.T) {
+	numbers := []int{4, 8, 15, 16, 23, 42}
+
+	tests := []struct {
+		name   string
+		values []int
+		all    bool
+		any    bool
+	}{
+		{"no values", nil, true, false},
+		{"one present", []int{15}, true, true},
+		{"all present", []int{42, 4, 16}, true, true},
+		{"duplicates", []int{8, 8}, true, true},
+		{"some present", []int{4, 5}, false, true},
+		{"none present", []int{1, 2}, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := iters.ContainsAll(slices.Values(numbers), test.values...); got != test.all {
+				t.Fatalf("ContainsAll: expected %v, got %v", test.all, got)
+			}
+			if got := iters.ContainsAny(slices.Values(numbers), test.values...); got != test.any {
+				t.Fatalf("ContainsAny: expected %v, got %v", test.any, got)
+			}
+		})
+	}
+}
+
+func TestContainsAllAnyStopEarly(t *testing.T) {
+	src, c := iterstest.Counting(iterstest.Naturals())
+
+	if !iters.ContainsAll(src, 3, 1, 2) || c.Pulled() != 4 {
+		t.Fatalf("ContainsAll: expected to stop after 4 elements, pulled %d", c.Pulled())
+	}
+
+	c.Reset()
+	if !iters.ContainsAny(src, 9, 5) || c.Pulled() != 6 {
+		t.Fatalf("ContainsAny: expected to stop after 6 elements, pulled %d", c.Pulled())
+	}
+}
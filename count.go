@@ -0,0 +1,43 @@
+package iters
+
+import "iter"
+
+// Count returns the number of elements in seq, which it reads to the end.
+func Count[T any](seq iter.Seq[T]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+// CountFunc returns the number of elements in seq that satisfy pred.
+func CountFunc[T any](seq iter.Seq[T], pred Predicate[T]) int {
+	n := 0
+	for item := range seq {
+		if pred(item) {
+			n++
+		}
+	}
+	return n
+}
+
+// Count2 returns the number of pairs in seq2, which it reads to the end.
+func Count2[K, V any](seq2 iter.Seq2[K, V]) int {
+	n := 0
+	for range seq2 {
+		n++
+	}
+	return n
+}
+
+// CountFunc2 returns the number of pairs in seq2 that satisfy pred.
+func CountFunc2[K, V any](seq2 iter.Seq2[K, V], pred Predicate2[K, V]) int {
+	n := 0
+	for k, v := range seq2 {
+		if pred(k, v) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,47 @@
+package iters_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleCountFunc() {
+	even := func(n int) bool { return n%2 == 0 }
+	fmt.Println(iters.CountFunc(slices.Values([]int{1, 2, 3, 4, 6}), even))
+	// Output:
+	// 3
+}
+
+func TestCount(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(0, 9), 20), func(s []int) bool {
+		return iters.Count(slices.Values(s)) == len(s) &&
+			iters.Count2(slices.All(s)) == len(s)
+	})
+}
+
+func TestCountFunc(t *testing.T) {
+	odd := func(n int) bool { return n%2 != 0 }
+	gen.ForAll(t, gen.Slice(gen.Int(0, 9), 20), func(s []int) bool {
+		want := len(slices.Collect(iters.Filter(slices.Values(s), odd)))
+		return iters.CountFunc(slices.Values(s), odd) == want
+	})
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	if got := iters.CountFunc2(maps.All(m), func(k string, v int) bool { return k != "a" && v > 1 }); got != 2 {
+		t.Fatalf("CountFunc2: expected 2, got %d", got)
+	}
+}
+
+func TestStreamCountFunc(t *testing.T) {
+	if got := iters.From(slices.Values([]int{1, 2, 3})).CountFunc(func(n int) bool { return n > 1 }); got != 2 {
+		t.Fatalf("Stream.CountFunc: expected 2, got %d", got)
+	}
+	if got := iters.From2(slices.All([]int{5, 6, 7})).CountFunc(func(i, n int) bool { return i == n-5 }); got != 3 {
+		t.Fatalf("Stream2.CountFunc: expected 3, got %d", got)
+	}
+}
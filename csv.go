@@ -0,0 +1,391 @@
+package iters
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions configures ReadCSV and WriteCSV. The zero value reads and
+// writes comma separated records, matches columns using the "csv" struct
+// tag and formats times with [time.RFC3339].
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+
+	// Comment, if non-zero, marks lines beginning with it as comments that
+	// ReadCSV skips.
+	Comment rune
+
+	// TrimLeadingSpace causes leading white space in a field to be ignored
+	// when reading.
+	TrimLeadingSpace bool
+
+	// Tag is the struct tag key used to name columns. It defaults to "csv".
+	Tag string
+
+	// TimeLayout is the layout used to parse and format time.Time fields.
+	// It defaults to time.RFC3339.
+	TimeLayout string
+}
+
+// CSVError describes a record that could not be converted into a struct.
+type CSVError struct {
+	// Row is the 1-based index of the data record, not counting the header.
+	Row int
+	// Line and Column locate the offending field in the input, as reported
+	// by [csv.Reader.FieldPos].
+	Line, Column int
+	// Field is the header name of the offending column. It is empty when
+	// a record has more cells than the header.
+	Field string
+	// Err is the underlying conversion error.
+	Err error
+}
+
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("iters: csv row %d (line %d, column %d), field %q: %v", e.Row, e.Line, e.Column, e.Field, e.Err)
+}
+
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// ReadCSV returns a sequence of T decoded from the CSV records in r. The
+// first record is treated as a header and its columns are matched against
+// the exported fields of the struct type T, using the name given by the
+// struct tag (see CSVOptions.Tag) or the field name when there is no tag.
+// A tag of "-" skips the field and unknown columns are ignored.
+//
+// Supported field types are strings, booleans, integers, floating-point
+// numbers, time.Time, time.Duration and types implementing
+// [encoding.TextUnmarshaler]. Empty cells leave non-string fields at their
+// zero value.
+//
+// A record that fails to convert, or that has more or fewer cells than the
+// header, yields a *CSVError and iteration continues with the next record.
+// For a ragged record the error wraps [csv.ErrFieldCount]. Errors reading
+// r are yielded once and end the sequence. A nil opts uses the defaults.
+func ReadCSV[T any](r io.Reader, opts *CSVOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		o := csvDefaults(opts)
+
+		fields, err := csvFieldsFor(reflect.TypeFor[T](), o.Tag)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		cr := csv.NewReader(r)
+		cr.Comma = o.Comma
+		cr.Comment = o.Comment
+		cr.TrimLeadingSpace = o.TrimLeadingSpace
+		cr.ReuseRecord = true
+		// Ragged records are reported per row below rather than by the
+		// reader, which would end the sequence.
+		cr.FieldsPerRecord = -1
+
+		header, err := cr.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		// Spreadsheet exports often start with a UTF-8 byte order mark.
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+		byName := make(map[string]*csvField, len(fields))
+		for i := range fields {
+			byName[fields[i].name] = &fields[i]
+		}
+
+		var (
+			names   = make([]string, len(header))
+			columns = make([]*csvField, len(header))
+		)
+		for i, name := range header {
+			names[i] = name
+			columns[i] = byName[name]
+		}
+
+		for row := 1; ; row++ {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var (
+				item   T
+				v      = reflect.ValueOf(&item).Elem()
+				rowErr error
+			)
+			if len(record) != len(header) {
+				rowErr = csvFieldCountError(cr, row, record, names)
+			}
+			for i, cell := range record {
+				if rowErr != nil {
+					break
+				}
+				if i >= len(columns) || columns[i] == nil {
+					continue
+				}
+				if err := o.decode(v.Field(columns[i].index), cell); err != nil {
+					line, column := cr.FieldPos(i)
+					rowErr = &CSVError{Row: row, Line: line, Column: column, Field: names[i], Err: err}
+					break
+				}
+			}
+
+			if rowErr != nil {
+				if !yield(zero, rowErr) {
+					return
+				}
+				continue
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// csvFieldCountError reports a record with more or fewer cells than the
+// header. It points at the first extra cell, or at the last cell of a
+// short record and names the first missing column.
+func csvFieldCountError(cr *csv.Reader, row int, record, names []string) error {
+	i, field := len(names), ""
+	if len(record) < len(names) {
+		i, field = len(record)-1, names[len(record)]
+	}
+	line, column := cr.FieldPos(i)
+	return &CSVError{Row: row, Line: line, Column: column, Field: field, Err: csv.ErrFieldCount}
+}
+
+// WriteCSV writes a header derived from the struct type T followed by one
+// record per element of seq. Columns are named and encoded following the
+// same rules as ReadCSV, so its output can be read back with the same
+// options. A nil opts uses the defaults.
+func WriteCSV[T any](w io.Writer, seq iter.Seq[T], opts *CSVOptions) error {
+	o := csvDefaults(opts)
+
+	fields, err := csvFieldsFor(reflect.TypeFor[T](), o.Tag)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = o.Comma
+
+	record := make([]string, len(fields))
+	for i, f := range fields {
+		record[i] = f.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+
+	row := 0
+	for item := range seq {
+		row++
+		v := reflect.ValueOf(&item).Elem()
+		for i, f := range fields {
+			s, err := o.encode(v.Field(f.index))
+			if err != nil {
+				return &CSVError{Row: row, Column: i + 1, Field: f.name, Err: err}
+			}
+			record[i] = s
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvField maps a CSV column to a struct field.
+type csvField struct {
+	name  string
+	index int
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// csvFieldsFor returns the columns for the struct type t in field order.
+func csvFieldsFor(t reflect.Type, tag string) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("iters: csv requires a struct type, got %s", t)
+	}
+
+	var fields []csvField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := sf.Name
+		if value, ok := sf.Tag.Lookup(tag); ok {
+			if value == "-" {
+				continue
+			}
+			if value, _, _ = strings.Cut(value, ","); value != "" {
+				name = value
+			}
+		}
+
+		if !csvSupported(sf.Type) {
+			return nil, fmt.Errorf("iters: csv field %s has unsupported type %s", sf.Name, sf.Type)
+		}
+
+		fields = append(fields, csvField{name: name, index: i})
+	}
+	return fields, nil
+}
+
+func csvSupported(t reflect.Type) bool {
+	if t == timeType || t == durationType {
+		return true
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// csvDefaults returns a copy of opts with unset fields filled in.
+func csvDefaults(opts *CSVOptions) CSVOptions {
+	var out CSVOptions
+	if opts != nil {
+		out = *opts
+	}
+	if out.Comma == 0 {
+		out.Comma = ','
+	}
+	if out.Tag == "" {
+		out.Tag = "csv"
+	}
+	if out.TimeLayout == "" {
+		out.TimeLayout = time.RFC3339
+	}
+	return out
+}
+
+// decode parses s into v.
+func (o CSVOptions) decode(v reflect.Value, s string) error {
+	if s == "" && v.Kind() != reflect.String {
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		t, err := time.Parse(o.TimeLayout, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return errors.New("unsupported type " + v.Type().String())
+	}
+	return nil
+}
+
+// encode formats v as a CSV cell.
+func (o CSVOptions) encode(v reflect.Value) (string, error) {
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time).Format(o.TimeLayout), nil
+	case durationType:
+		return time.Duration(v.Int()).String(), nil
+	}
+
+	if v.Type().Implements(textMarshalerType) || reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+		m := v.Addr().Interface().(encoding.TextMarshaler)
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", errors.New("unsupported type " + v.Type().String())
+}
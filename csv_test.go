@@ -0,0 +1,304 @@
+package iters_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/picatz/iters"
+)
+
+func ExampleReadCSV() {
+	type Animal struct {
+		Name string `csv:"name"`
+		Legs int    `csv:"legs"`
+	}
+
+	input := strings.NewReader("name,legs\ncat,4\nfish,0\nbird,2\n")
+
+	animals := iters.Filter(
+		iters.UntilErr(iters.ReadCSV[Animal](input, nil)),
+		func(animal Animal) bool {
+			return animal.Legs > 0
+		},
+	)
+
+	fmt.Println(slices.Collect(animals))
+	// Output:
+	// [{cat 4} {bird 2}]
+}
+
+func ExampleWriteCSV() {
+	type Animal struct {
+		Name string `csv:"name"`
+		Legs int    `csv:"legs"`
+	}
+
+	animals := []Animal{{"cat", 4}, {"bird", 2}}
+
+	if err := iters.WriteCSV(os.Stdout, slices.Values(animals), nil); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// name,legs
+	// cat,4
+	// bird,2
+}
+
+type csvRecord struct {
+	Name     string        `csv:"name"`
+	Count    int16         `csv:"count"`
+	Size     uint          `csv:"size"`
+	Ratio    float64       `csv:"ratio"`
+	Active   bool          `csv:"active"`
+	When     time.Time     `csv:"when"`
+	Timeout  time.Duration `csv:"timeout"`
+	Level    csvLevel      `csv:"level"`
+	Ignored  string        `csv:"-"`
+	Untagged string
+	hidden   string
+}
+
+type csvLevel int
+
+func (l *csvLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func (l csvLevel) MarshalText() ([]byte, error) {
+	switch l {
+	case 1:
+		return []byte("low"), nil
+	case 2:
+		return []byte("high"), nil
+	}
+	return nil, nil
+}
+
+type readCSVTableTest struct {
+	name     string
+	input    string
+	opts     *iters.CSVOptions
+	expected []csvRecord
+}
+
+func (test readCSVTableTest) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		got, err := iters.CollectErr(iters.ReadCSV[csvRecord](strings.NewReader(test.input), test.opts))
+		if err != nil {
+			t.Fatalf("ReadCSV: unexpected error %v", err)
+		}
+		if !slices.Equal(got, test.expected) {
+			t.Fatalf("ReadCSV: expected %+v, got %+v", test.expected, got)
+		}
+	})
+}
+
+func TestReadCSV(t *testing.T) {
+	when := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []runnableTest{
+		readCSVTableTest{
+			name:     "empty input",
+			input:    "",
+			expected: nil,
+		},
+		readCSVTableTest{
+			name:     "header only",
+			input:    "name,count\n",
+			expected: nil,
+		},
+		readCSVTableTest{
+			name:  "all supported types",
+			input: "name,count,size,ratio,active,when,timeout,level,Untagged\nalpha,-3,7,0.5,true,2024-05-01T12:30:00Z,1m30s,high,x\n",
+			expected: []csvRecord{
+				{Name: "alpha", Count: -3, Size: 7, Ratio: 0.5, Active: true, When: when, Timeout: 90 * time.Second, Level: 2, Untagged: "x"},
+			},
+		},
+		readCSVTableTest{
+			name:  "columns in any order with unknown and ignored columns",
+			input: "extra,count,name,-\n?,1,a,skip\n?,2,b,skip\n",
+			expected: []csvRecord{
+				{Name: "a", Count: 1},
+				{Name: "b", Count: 2},
+			},
+		},
+		readCSVTableTest{
+			name:  "empty cells keep zero values",
+			input: "name,count,active,when\nblank,,,\n",
+			expected: []csvRecord{
+				{Name: "blank"},
+			},
+		},
+		readCSVTableTest{
+			name:  "byte order mark",
+			input: "\ufeffname,count\na,1\n",
+			expected: []csvRecord{
+				{Name: "a", Count: 1},
+			},
+		},
+		readCSVTableTest{
+			name:  "custom options",
+			input: "# exported\nname;when\na;2024-05-01\n",
+			opts:  &iters.CSVOptions{Comma: ';', Comment: '#', TimeLayout: time.DateOnly},
+			expected: []csvRecord{
+				{Name: "a", When: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestReadCSVConversionError(t *testing.T) {
+	input := "name,count\na,1\nb,many\nc,3\n"
+
+	var (
+		names []string
+		errs  []error
+	)
+	for record, err := range iters.ReadCSV[csvRecord](strings.NewReader(input), nil) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, record.Name)
+	}
+
+	if want := []string{"a", "c"}; !slices.Equal(names, want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", errs)
+	}
+
+	var csvErr *iters.CSVError
+	if !errors.As(errs[0], &csvErr) {
+		t.Fatalf("expected *iters.CSVError, got %T", errs[0])
+	}
+	if csvErr.Row != 2 || csvErr.Line != 3 || csvErr.Column != 3 || csvErr.Field != "count" {
+		t.Fatalf("unexpected error position %+v", csvErr)
+	}
+	if !errors.Is(errs[0], strconv.ErrSyntax) {
+		t.Fatalf("expected wrapped strconv.ErrSyntax, got %v", errs[0])
+	}
+}
+
+func TestReadCSVRaggedRows(t *testing.T) {
+	input := "name,count,note\na,1,x\nb,2\nc,3,y,extra\nd,4,z\n"
+
+	var (
+		names []string
+		errs  []*iters.CSVError
+	)
+	for record, err := range iters.ReadCSV[csvRecord](strings.NewReader(input), nil) {
+		if err != nil {
+			var csvErr *iters.CSVError
+			if !errors.As(err, &csvErr) || !errors.Is(err, csv.ErrFieldCount) {
+				t.Fatalf("expected a *iters.CSVError wrapping csv.ErrFieldCount, got %v", err)
+			}
+			errs = append(errs, csvErr)
+			continue
+		}
+		names = append(names, record.Name)
+	}
+
+	if want := []string{"a", "d"}; !slices.Equal(names, want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected two errors, got %v", errs)
+	}
+	if e := errs[0]; e.Row != 2 || e.Line != 3 || e.Column != 3 || e.Field != "note" {
+		t.Fatalf("unexpected error position for the short row %+v", e)
+	}
+	if e := errs[1]; e.Row != 3 || e.Line != 4 || e.Column != 7 || e.Field != "" {
+		t.Fatalf("unexpected error position for the long row %+v", e)
+	}
+}
+
+func TestReadCSVParseErrorEndsSequence(t *testing.T) {
+	input := "name,count\na,1\nb,\"2\"x\nc,3\n"
+
+	got, err := iters.CollectErr(iters.ReadCSV[csvRecord](strings.NewReader(input), nil))
+	if err == nil {
+		t.Fatalf("expected a parse error")
+	}
+	if len(got) != 1 || got[0].Name != "a" {
+		t.Fatalf("expected only the first record, got %+v", got)
+	}
+}
+
+func TestReadCSVUnsupportedType(t *testing.T) {
+	type unsupported struct {
+		Tags []string
+	}
+
+	_, err := iters.CollectErr(iters.ReadCSV[unsupported](strings.NewReader("Tags\nx\n"), nil))
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported field type")
+	}
+
+	_, err = iters.CollectErr(iters.ReadCSV[int](strings.NewReader("x\n1\n"), nil))
+	if err == nil {
+		t.Fatalf("expected an error for a non-struct type")
+	}
+}
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	records := []csvRecord{
+		{Name: "alpha, with comma", Count: 1, Size: 2, Ratio: 0.25, Active: true, When: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC), Timeout: time.Second, Level: 1, Ignored: "dropped", Untagged: "u"},
+		{Name: "beta", Count: -1},
+	}
+
+	var buf strings.Builder
+	if err := iters.WriteCSV(&buf, slices.Values(records), nil); err != nil {
+		t.Fatalf("WriteCSV: unexpected error %v", err)
+	}
+
+	header, _, _ := strings.Cut(buf.String(), "\n")
+	if want := "name,count,size,ratio,active,when,timeout,level,Untagged"; header != want {
+		t.Fatalf("expected header %q, got %q", want, header)
+	}
+
+	got, err := iters.CollectErr(iters.ReadCSV[csvRecord](strings.NewReader(buf.String()), nil))
+	if err != nil {
+		t.Fatalf("ReadCSV: unexpected error %v", err)
+	}
+
+	records[0].Ignored = ""
+	if !slices.Equal(got, records) {
+		t.Fatalf("expected %+v, got %+v", records, got)
+	}
+}
+
+func TestWriteCSVEmpty(t *testing.T) {
+	type row struct {
+		A string
+		B int
+	}
+
+	var buf strings.Builder
+	if err := iters.WriteCSV(&buf, slices.Values([]row(nil)), &iters.CSVOptions{Comma: '\t'}); err != nil {
+		t.Fatalf("WriteCSV: unexpected error %v", err)
+	}
+	if want := "A\tB\n"; buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
@@ -0,0 +1,137 @@
+package iters
+
+import (
+	"container/list"
+	"iter"
+)
+
+// DedupOptions configures UniqueBy and CompactBy. A nil *DedupOptions
+// keeps the first element for each key and remembers every key.
+type DedupOptions struct {
+	// KeepLast yields the last element of each group of equal keys
+	// instead of the first. For UniqueBy this means the whole input is
+	// read before anything is yielded, and elements are yielded in the
+	// order of their last occurrence. For CompactBy it delays each element
+	// until the end of its run is seen.
+	KeepLast bool
+
+	// MaxKeys, if positive, bounds the memory of UniqueBy by remembering
+	// only the MaxKeys most recently seen keys. A key that was forgotten
+	// is treated as new when it reappears, so duplicates further apart
+	// than MaxKeys distinct keys are yielded again. This makes UniqueBy
+	// usable on infinite sequences. MaxKeys is ignored when KeepLast is
+	// set, and by CompactBy, which only ever remembers one key.
+	MaxKeys int
+}
+
+// UniqueBy returns a sequence that yields one element of seq for each
+// distinct key returned by keyFn, in first occurrence order. Keys are kept
+// in a map, so each element costs O(1) regardless of how many keys have
+// been seen, unlike [UniqueFunc]. See DedupOptions for keeping the last
+// occurrence instead and for bounding memory.
+func UniqueBy[T any, K comparable](seq iter.Seq[T], keyFn func(T) K, opts *DedupOptions) iter.Seq[T] {
+	switch {
+	case opts != nil && opts.KeepLast:
+		return uniqueByLast(seq, keyFn)
+	case opts != nil && opts.MaxKeys > 0:
+		return uniqueByLRU(seq, keyFn, opts.MaxKeys)
+	}
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for item := range seq {
+			k := keyFn(item)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func uniqueByLast[T any, K comparable](seq iter.Seq[T], keyFn func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// keep[i] reports whether items[i] is still the last element seen
+		// with its key, so keyFn is called once per element.
+		var (
+			items []T
+			keep  []bool
+		)
+		last := make(map[K]int)
+		for item := range seq {
+			k := keyFn(item)
+			if i, ok := last[k]; ok {
+				keep[i] = false
+			}
+			last[k] = len(items)
+			items = append(items, item)
+			keep = append(keep, true)
+		}
+		for i, item := range items {
+			if !keep[i] {
+				continue
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func uniqueByLRU[T any, K comparable](seq iter.Seq[T], keyFn func(T) K, maxKeys int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// recent orders the remembered keys from most to least recently
+		// seen; index locates each key's element in recent.
+		recent := list.New()
+		index := make(map[K]*list.Element, maxKeys)
+		for item := range seq {
+			k := keyFn(item)
+			if e, ok := index[k]; ok {
+				recent.MoveToFront(e)
+				continue
+			}
+			if recent.Len() == maxKeys {
+				delete(index, recent.Remove(recent.Back()).(K))
+			}
+			index[k] = recent.PushFront(k)
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// CompactBy collapses runs of consecutive elements of seq that have equal
+// keys, yielding the first element of each run, or the last when
+// opts.KeepLast is set.
+func CompactBy[T any, K comparable](seq iter.Seq[T], keyFn func(T) K, opts *DedupOptions) iter.Seq[T] {
+	keepLast := opts != nil && opts.KeepLast
+	return func(yield func(T) bool) {
+		var (
+			started bool
+			prevKey K
+			pending T
+		)
+		for item := range seq {
+			k := keyFn(item)
+			if started && k == prevKey {
+				if keepLast {
+					pending = item
+				}
+				continue
+			}
+			if started && keepLast && !yield(pending) {
+				return
+			}
+			started, prevKey, pending = true, k, item
+			if !keepLast && !yield(item) {
+				return
+			}
+		}
+		if started && keepLast {
+			yield(pending)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package iters_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+type dedupEvent struct {
+	ID      int
+	Payload string
+}
+
+func eventID(e dedupEvent) int { return e.ID }
+
+func ExampleUniqueBy() {
+	events := slices.Values([]dedupEvent{{1, "a"}, {2, "b"}, {1, "c"}, {3, "d"}, {2, "e"}})
+
+	fmt.Println(slices.Collect(iters.UniqueBy(events, eventID, nil)))
+	fmt.Println(slices.Collect(iters.UniqueBy(events, eventID, &iters.DedupOptions{KeepLast: true})))
+	// Output:
+	// [{1 a} {2 b} {3 d}]
+	// [{1 c} {3 d} {2 e}]
+}
+
+func ExampleCompactBy() {
+	events := slices.Values([]dedupEvent{{1, "a"}, {1, "b"}, {2, "c"}, {1, "d"}})
+
+	fmt.Println(slices.Collect(iters.CompactBy(events, eventID, nil)))
+	fmt.Println(slices.Collect(iters.CompactBy(events, eventID, &iters.DedupOptions{KeepLast: true})))
+	// Output:
+	// [{1 a} {2 c} {1 d}]
+	// [{1 b} {2 c} {1 d}]
+}
+
+var dedupEvents = gen.Slice(func(r *rand.Rand) dedupEvent {
+	return dedupEvent{ID: gen.Int(0, 5)(r), Payload: gen.String("xyz", 2)(r)}
+}, 30)
+
+func TestUniqueByMatchesUniqueFunc(t *testing.T) {
+	sameID := func(a, b dedupEvent) bool { return a.ID == b.ID }
+	gen.ForAll(t, dedupEvents, func(events []dedupEvent) bool {
+		got := slices.Collect(iters.UniqueBy(slices.Values(events), eventID, nil))
+		return slices.Equal(got, slices.Collect(iters.UniqueFunc(slices.Values(events), sameID)))
+	})
+}
+
+func TestUniqueByKeepLast(t *testing.T) {
+	gen.ForAll(t, dedupEvents, func(events []dedupEvent) bool {
+		// Keeping the last occurrence is keeping the first occurrence of
+		// the reversed input, reversed back.
+		reversed := slices.Clone(events)
+		slices.Reverse(reversed)
+		want := slices.Collect(iters.UniqueBy(slices.Values(reversed), eventID, nil))
+		slices.Reverse(want)
+
+		calls := 0
+		countedID := func(e dedupEvent) int { calls++; return e.ID }
+		got := slices.Collect(iters.UniqueBy(slices.Values(events), countedID, &iters.DedupOptions{KeepLast: true}))
+		return slices.Equal(got, want) && calls == len(events)
+	})
+}
+
+func TestUniqueByMaxKeys(t *testing.T) {
+	ids := []int{1, 2, 1, 3, 4, 1, 2, 5, 2}
+	events := iters.Map(slices.Values(ids), func(id int) dedupEvent { return dedupEvent{ID: id} })
+
+	// With room for three keys: 1 stays fresh by reappearing, so it is
+	// suppressed, while 2 is forgotten after 3 and 4 and reappears as new.
+	got := slices.Collect(iters.Map(iters.UniqueBy(events, eventID, &iters.DedupOptions{MaxKeys: 3}), eventID))
+	if want := []int{1, 2, 3, 4, 2, 5}; !slices.Equal(got, want) {
+		t.Fatalf("UniqueBy: expected %v, got %v", want, got)
+	}
+
+	// A bounded cache works on an infinite sequence.
+	modulo := func(n int) int { return n % 10 }
+	if got := slices.Collect(iters.Limit(iters.UniqueBy(iterstest.Naturals(), modulo, &iters.DedupOptions{MaxKeys: 5}), 12)); len(got) != 12 {
+		t.Fatalf("UniqueBy: expected 12 elements from an infinite sequence, got %v", got)
+	}
+	iterstest.CheckSeq(t, iters.UniqueBy(iterstest.Naturals(), modulo, &iters.DedupOptions{MaxKeys: 3}))
+}
+
+func TestUniqueByScales(t *testing.T) {
+	const n = 200_000
+	rows := iters.Map(iters.Range(0, n, 1), func(i int) dedupEvent { return dedupEvent{ID: i % (n / 2)} })
+
+	count := 0
+	for range iters.UniqueBy(rows, eventID, nil) {
+		count++
+	}
+	if count != n/2 {
+		t.Fatalf("UniqueBy: expected %d unique rows, got %d", n/2, count)
+	}
+}
+
+func TestCompactByMatchesCompactFunc(t *testing.T) {
+	sameID := func(a, b dedupEvent) bool { return a.ID == b.ID }
+	gen.ForAll(t, dedupEvents, func(events []dedupEvent) bool {
+		first := slices.Collect(iters.CompactBy(slices.Values(events), eventID, nil))
+		last := slices.Collect(iters.CompactBy(slices.Values(events), eventID, &iters.DedupOptions{KeepLast: true}))
+		if !slices.Equal(first, slices.Collect(iters.CompactFunc(slices.Values(events), sameID))) || len(last) != len(first) {
+			return false
+		}
+		for i := range first {
+			if first[i].ID != last[i].ID {
+				return false
+			}
+		}
+		return true
+	})
+
+	iterstest.CheckSeq(t, iters.CompactBy(slices.Values([]int{1, 1, 2, 3, 3}), func(n int) int { return n }, &iters.DedupOptions{KeepLast: true}))
+}
@@ -0,0 +1,306 @@
+package iters
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+)
+
+// EditKind is the kind of an Edit.
+type EditKind int
+
+const (
+	// EditKeep is an element common to both sequences.
+	EditKeep EditKind = iota
+	// EditDelete is an element of the first sequence missing from the
+	// second.
+	EditDelete
+	// EditInsert is an element of the second sequence missing from the
+	// first.
+	EditInsert
+)
+
+// String returns "keep", "delete" or "insert".
+func (k EditKind) String() string {
+	switch k {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	}
+	return "EditKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Edit is one operation of an edit script produced by Diff.
+type Edit[T any] struct {
+	Kind EditKind
+
+	// Value is the element kept, deleted or inserted. A kept element is
+	// taken from the first sequence.
+	Value T
+
+	// A and B are the positions of the edit in the first and second
+	// sequences. An insert has no position in the first sequence, so its A
+	// is the number of elements of the first sequence that precede it, and
+	// likewise B for a delete.
+	A, B int
+}
+
+// DiffOptions configures Diff and DiffFunc. A nil *DiffOptions computes
+// the whole edit script before yielding it.
+type DiffOptions struct {
+	// LinearSpace finds the edit script by divide and conquer, using
+	// memory proportional to the length of the inputs rather than to the
+	// square of the number of differences. It takes about twice as long,
+	// but edits are yielded as they are found, and the script may differ
+	// from the default one when several shortest scripts exist.
+	LinearSpace bool
+}
+
+// Diff returns a shortest edit script that turns a into b, computed with
+// Myers' O(ND) difference algorithm. Applying the edits in order, keeping
+// and deleting elements of a and inserting elements of b, produces b.
+//
+// Both sequences are collected when iteration starts, so they must be
+// finite. Unless opts asks for linear space, memory grows with the square
+// of the number of differences, which suits inputs that mostly agree.
+func Diff[T comparable](a, b iter.Seq[T], opts *DiffOptions) iter.Seq[Edit[T]] {
+	return DiffFunc(a, b, func(x, y T) bool { return x == y }, opts)
+}
+
+// DiffFunc is like Diff but compares elements with eq.
+func DiffFunc[T any](a, b iter.Seq[T], eq func(x, y T) bool, opts *DiffOptions) iter.Seq[Edit[T]] {
+	linear := opts != nil && opts.LinearSpace
+	return func(yield func(Edit[T]) bool) {
+		d := &differ[T]{a: slices.Collect(a), b: slices.Collect(b), eq: eq, yield: yield}
+		if linear {
+			d.linear(0, len(d.a), 0, len(d.b))
+			return
+		}
+		for _, e := range d.shortest() {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// differ holds the state of a single diff.
+type differ[T any] struct {
+	a, b  []T
+	eq    func(x, y T) bool
+	yield func(Edit[T]) bool
+
+	// vf and vb are the furthest reaching x positions of the forward and
+	// backward searches for a middle snake, indexed by diagonal.
+	vf, vb []int
+	done   bool
+}
+
+// shortest runs the greedy forward search of Myers' algorithm, keeping the
+// furthest reaching positions of every round so that the path can be
+// traced back from the end.
+func (d *differ[T]) shortest() []Edit[T] {
+	n, m := len(d.a), len(d.b)
+	offset := n + m
+	v := make([]int, 2*offset+2)
+
+	// trace[i] holds the furthest reaching x on diagonals -i through i
+	// after round i.
+	var trace [][]int
+	for rounds, found := 0, false; !found; rounds++ {
+		for k := -rounds; k <= rounds; k += 2 {
+			var x int
+			if k == -rounds || (k != rounds && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // down, inserting from b
+			} else {
+				x = v[offset+k-1] + 1 // right, deleting from a
+			}
+			y := x - k
+			for x < n && y < m && d.eq(d.a[x], d.b[y]) {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+		trace = append(trace, slices.Clone(v[offset-rounds:offset+rounds+1]))
+	}
+
+	edits := make([]Edit[T], 0, max(n, m))
+	x, y := n, m
+	for rounds := len(trace) - 1; rounds > 0; rounds-- {
+		prev := trace[rounds-1]
+		at := func(k int) int { return prev[k+rounds-1] }
+
+		k := x - y
+		var prevK int
+		if k == -rounds || (k != rounds && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			edits = append(edits, Edit[T]{Kind: EditKeep, Value: d.a[x], A: x, B: y})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, Edit[T]{Kind: EditInsert, Value: d.b[y], A: x, B: y})
+		} else {
+			x--
+			edits = append(edits, Edit[T]{Kind: EditDelete, Value: d.a[x], A: x, B: y})
+		}
+	}
+	for x > 0 {
+		x, y = x-1, y-1
+		edits = append(edits, Edit[T]{Kind: EditKeep, Value: d.a[x], A: x, B: y})
+	}
+	slices.Reverse(edits)
+	return edits
+}
+
+// emit yields e unless the consumer has already stopped.
+func (d *differ[T]) emit(e Edit[T]) bool {
+	if d.done {
+		return false
+	}
+	if !d.yield(e) {
+		d.done = true
+	}
+	return !d.done
+}
+
+// keep, remove and insert yield the edits for a[x:u] kept as b[y:], a[lo:hi]
+// deleted before b[y] and b[lo:hi] inserted before a[x].
+func (d *differ[T]) keep(x, u, y int) bool {
+	for ; x < u; x, y = x+1, y+1 {
+		if !d.emit(Edit[T]{Kind: EditKeep, Value: d.a[x], A: x, B: y}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *differ[T]) remove(lo, hi, y int) bool {
+	for x := lo; x < hi; x++ {
+		if !d.emit(Edit[T]{Kind: EditDelete, Value: d.a[x], A: x, B: y}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *differ[T]) insert(lo, hi, x int) bool {
+	for y := lo; y < hi; y++ {
+		if !d.emit(Edit[T]{Kind: EditInsert, Value: d.b[y], A: x, B: y}) {
+			return false
+		}
+	}
+	return true
+}
+
+// linear yields the edits turning a[aLo:aHi] into b[bLo:bHi], splitting the
+// problem at a middle snake as in section 4b of Myers' paper. It returns
+// false once the consumer stops.
+func (d *differ[T]) linear(aLo, aHi, bLo, bHi int) bool {
+	// Common prefixes and suffixes are kept without searching.
+	start := aLo
+	for aLo < aHi && bLo < bHi && d.eq(d.a[aLo], d.b[bLo]) {
+		aLo, bLo = aLo+1, bLo+1
+	}
+	if !d.keep(start, aLo, bLo-(aLo-start)) {
+		return false
+	}
+	end := aHi
+	for aLo < aHi && bLo < bHi && d.eq(d.a[aHi-1], d.b[bHi-1]) {
+		aHi, bHi = aHi-1, bHi-1
+	}
+
+	var ok bool
+	switch {
+	case aLo == aHi:
+		ok = d.insert(bLo, bHi, aLo)
+	case bLo == bHi:
+		ok = d.remove(aLo, aHi, bLo)
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		if (x == aHi && y == bHi) || (u == aLo && v == bLo) {
+			// The split must make progress. With the prefix and suffix
+			// removed there are at least two differences, so this never
+			// happens, but it guarantees termination.
+			ok = d.remove(aLo, aHi, bLo) && d.insert(bLo, bHi, aHi)
+			break
+		}
+		ok = d.linear(aLo, x, bLo, y) && d.keep(x, u, y) && d.linear(u, aHi, v, bHi)
+	}
+	return ok && d.keep(aHi, end, bHi)
+}
+
+// middleSnake searches from both ends of a[aLo:aHi] and b[bLo:bHi] at once
+// and returns the diagonal run (x, y) to (u, v) where the searches first
+// overlap, which lies on a shortest path. Both ranges must be non-empty.
+func (d *differ[T]) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	limit := (n + m + 1) / 2
+
+	size := 2*limit + 2
+	if cap(d.vf) < size {
+		d.vf, d.vb = make([]int, size), make([]int, size)
+	}
+	vf, vb := d.vf[:size], d.vb[:size]
+	offset := limit
+	vf[offset+1], vb[offset+1] = 0, 0
+
+	for rounds := 0; rounds <= limit; rounds++ {
+		for k := -rounds; k <= rounds; k += 2 {
+			var px int
+			if k == -rounds || (k != rounds && vf[offset+k-1] < vf[offset+k+1]) {
+				px = vf[offset+k+1]
+			} else {
+				px = vf[offset+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for sx < n && sy < m && d.eq(d.a[aLo+sx], d.b[bLo+sy]) {
+				sx, sy = sx+1, sy+1
+			}
+			vf[offset+k] = sx
+
+			// The backward search, one round behind, runs along diagonal
+			// delta-k measured from the end.
+			if kb := delta - k; odd && kb >= -(rounds-1) && kb <= rounds-1 && sx+vb[offset+kb] >= n {
+				return aLo + px, bLo + py, aLo + sx, bLo + sy
+			}
+		}
+
+		for k := -rounds; k <= rounds; k += 2 {
+			var px int
+			if k == -rounds || (k != rounds && vb[offset+k-1] < vb[offset+k+1]) {
+				px = vb[offset+k+1]
+			} else {
+				px = vb[offset+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for sx < n && sy < m && d.eq(d.a[aHi-1-sx], d.b[bHi-1-sy]) {
+				sx, sy = sx+1, sy+1
+			}
+			vb[offset+k] = sx
+
+			if kf := delta - k; !odd && kf >= -rounds && kf <= rounds && vf[offset+kf]+sx >= n {
+				return aHi - sx, bHi - sy, aHi - px, bHi - py
+			}
+		}
+	}
+	panic("unreachable")
+}
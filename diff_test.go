@@ -0,0 +1,238 @@
+package iters_test
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleDiff() {
+	a := slices.Values(strings.Split("ABCABBA", ""))
+	b := slices.Values(strings.Split("CBABAC", ""))
+
+	for e := range iters.Diff(a, b, nil) {
+		fmt.Println(e.Kind, e.Value)
+	}
+	// Output:
+	// delete A
+	// delete B
+	// keep C
+	// insert B
+	// keep A
+	// keep B
+	// delete B
+	// keep A
+	// insert C
+}
+
+func ExampleUnified() {
+	before := strings.Split("alpha\nbeta\ngamma\ndelta\nepsilon", "\n")
+	after := strings.Split("alpha\nbeta\nGAMMA\ndelta\nepsilon", "\n")
+
+	edits := iters.Diff(slices.Values(before), slices.Values(after), nil)
+	fmt.Print(iters.Unified(edits, &iters.UnifiedOptions{FromFile: "before.txt", ToFile: "after.txt", Context: 1}))
+	// Output:
+	// --- before.txt
+	// +++ after.txt
+	// @@ -2,3 +2,3 @@
+	//  beta
+	// -gamma
+	// +GAMMA
+	//  delta
+}
+
+// applyEdits rebuilds both inputs of a diff from its edit script, checking
+// that the positions recorded in each edit are consistent.
+func applyEdits[T any](edits []iters.Edit[T]) (a, b []T, ok bool) {
+	for _, e := range edits {
+		if e.A != len(a) || e.B != len(b) {
+			return nil, nil, false
+		}
+		if e.Kind != iters.EditInsert {
+			a = append(a, e.Value)
+		}
+		if e.Kind != iters.EditDelete {
+			b = append(b, e.Value)
+		}
+	}
+	return a, b, true
+}
+
+// editDistance returns the number of inserts and deletes in a shortest edit
+// script, computed by dynamic programming.
+func editDistance(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		cur[0] = i + 1
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j]
+			} else {
+				cur[j+1] = 1 + min(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+var diffInputs = func(r *rand.Rand) [2][]string {
+	return [2][]string{
+		strings.Split(gen.String("abc", 12)(r), ""),
+		strings.Split(gen.String("abc", 12)(r), ""),
+	}
+}
+
+func TestDiffIsShortest(t *testing.T) {
+	for _, opts := range []*iters.DiffOptions{nil, {LinearSpace: true}} {
+		t.Run(fmt.Sprintf("LinearSpace=%v", opts != nil), func(t *testing.T) {
+			gen.ForAll(t, diffInputs, func(in [2][]string) bool {
+				edits := slices.Collect(iters.Diff(slices.Values(in[0]), slices.Values(in[1]), opts))
+				a, b, ok := applyEdits(edits)
+				if !ok || !slices.Equal(a, in[0]) || !slices.Equal(b, in[1]) {
+					return false
+				}
+				changes := iters.CountFunc(slices.Values(edits), func(e iters.Edit[string]) bool {
+					return e.Kind != iters.EditKeep
+				})
+				return changes == editDistance(in[0], in[1])
+			})
+		})
+	}
+}
+
+func TestDiffEdgeCases(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  []int
+		kinds []iters.EditKind
+	}{
+		{"both empty", nil, nil, nil},
+		{"a empty", nil, []int{1, 2}, []iters.EditKind{iters.EditInsert, iters.EditInsert}},
+		{"b empty", []int{1, 2}, nil, []iters.EditKind{iters.EditDelete, iters.EditDelete}},
+		{"equal", []int{1, 2}, []int{1, 2}, []iters.EditKind{iters.EditKeep, iters.EditKeep}},
+		{"replaced", []int{1}, []int{2}, []iters.EditKind{iters.EditDelete, iters.EditInsert}},
+	}
+	for _, test := range tests {
+		for _, opts := range []*iters.DiffOptions{nil, {LinearSpace: true}} {
+			t.Run(fmt.Sprintf("%s/LinearSpace=%v", test.name, opts != nil), func(t *testing.T) {
+				var kinds []iters.EditKind
+				for e := range iters.Diff(slices.Values(test.a), slices.Values(test.b), opts) {
+					kinds = append(kinds, e.Kind)
+				}
+				if !slices.Equal(kinds, test.kinds) {
+					t.Fatalf("Diff: expected %v, got %v", test.kinds, kinds)
+				}
+			})
+		}
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	a := slices.Values([]string{"Go", "is", "FUN"})
+	b := slices.Values([]string{"go", "IS", "fun", "!"})
+
+	edits := slices.Collect(iters.DiffFunc(a, b, strings.EqualFold, nil))
+	if len(edits) != 4 || edits[3].Kind != iters.EditInsert || edits[3].Value != "!" {
+		t.Fatalf("DiffFunc: expected three keeps and an insert, got %v", edits)
+	}
+	// Kept elements are taken from the first sequence.
+	if edits[2].Kind != iters.EditKeep || edits[2].Value != "FUN" {
+		t.Fatalf("DiffFunc: expected to keep FUN, got %v", edits[2])
+	}
+}
+
+func TestDiffLinearSpaceLarge(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	a := make([]int, 5000)
+	for i := range a {
+		a[i] = r.IntN(50)
+	}
+	b := slices.Clone(a)
+	for range 200 {
+		b = slices.Insert(b, r.IntN(len(b)), r.IntN(50))
+		i := r.IntN(len(b))
+		b = slices.Delete(b, i, i+1)
+	}
+
+	full := slices.Collect(iters.Diff(slices.Values(a), slices.Values(b), nil))
+	linear := slices.Collect(iters.Diff(slices.Values(a), slices.Values(b), &iters.DiffOptions{LinearSpace: true}))
+
+	count := func(edits []iters.Edit[int]) int {
+		return iters.CountFunc(slices.Values(edits), func(e iters.Edit[int]) bool { return e.Kind != iters.EditKeep })
+	}
+	if count(full) != count(linear) {
+		t.Fatalf("Diff: expected both modes to find %d changes, linear space found %d", count(full), count(linear))
+	}
+	gotA, gotB, ok := applyEdits(linear)
+	if !ok || !slices.Equal(gotA, a) || !slices.Equal(gotB, b) {
+		t.Fatalf("Diff: linear space script does not turn a into b")
+	}
+}
+
+func TestDiffStopsEarly(t *testing.T) {
+	a := slices.Values([]int{1, 2, 3, 4, 5})
+	b := slices.Values([]int{0, 2, 3, 6, 5})
+	for _, opts := range []*iters.DiffOptions{nil, {LinearSpace: true}} {
+		iterstest.CheckSeq(t, iters.Diff(a, b, opts))
+	}
+}
+
+func TestUnified(t *testing.T) {
+	lines := func(s string) iter.Seq[string] { return slices.Values(strings.Split(s, " ")) }
+
+	tests := []struct {
+		name    string
+		a, b    string
+		context int
+		want    string
+	}{
+		{
+			name: "identical", a: "a b c", b: "a b c",
+			want: "",
+		},
+		{
+			name: "separate hunks", a: "1 2 3 4 5 6 7 8", b: "1 x 3 4 5 6 7 y",
+			context: 1,
+			want: "--- a\n+++ b\n" +
+				"@@ -1,3 +1,3 @@\n 1\n-2\n+x\n 3\n" +
+				"@@ -7,2 +7,2 @@\n 7\n-8\n+y\n",
+		},
+		{
+			name: "merged hunks", a: "1 2 3 4 5", b: "1 x 3 y 5",
+			context: 1,
+			want: "--- a\n+++ b\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+x\n 3\n-4\n+y\n 5\n",
+		},
+		{
+			name: "insert without context", a: "1 2", b: "1 x 2",
+			context: -1,
+			want:    "--- a\n+++ b\n@@ -1,0 +2 @@\n+x\n",
+		},
+		{
+			name: "delete without context", a: "1 2 3", b: "1 3",
+			context: -1,
+			want:    "--- a\n+++ b\n@@ -2 +1,0 @@\n-2\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			edits := iters.Diff(lines(test.a), lines(test.b), nil)
+			got := iters.Unified(edits, &iters.UnifiedOptions{Context: test.context})
+			if got != test.want {
+				t.Fatalf("Unified: expected\n%s\ngot\n%s", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package iters
+
+import "iter"
+
+// Enumerate returns a sequence that pairs each element of seq with its
+// zero-based index, like slices.All does for a slice.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for item := range seq {
+			if !yield(i, item) {
+				return
+			}
+			i++
+		}
+	}
+}
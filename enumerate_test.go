@@ -0,0 +1,34 @@
+package iters_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func ExampleEnumerate() {
+	for i, s := range iters.Enumerate(slices.Values([]string{"a", "b"})) {
+		fmt.Println(i, s)
+	}
+	// Output:
+	// 0 a
+	// 1 b
+}
+
+func TestEnumerate(t *testing.T) {
+	input := []string{"x", "y", "z"}
+	var indexes []int
+	var values []string
+	for i, v := range iters.Enumerate(slices.Values(input)) {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	if !slices.Equal(indexes, []int{0, 1, 2}) || !slices.Equal(values, input) {
+		t.Fatalf("Enumerate: expected indexes [0 1 2] and %v, got %v and %v", input, indexes, values)
+	}
+
+	iterstest.CheckSeq2(t, iters.Enumerate(iterstest.Naturals()))
+}
@@ -0,0 +1,195 @@
+package iters
+
+import (
+	"cmp"
+	"iter"
+)
+
+// The functions in this file follow slices.Max and slices.Min for
+// floating-point NaN values: a NaN is more extreme than any other value, so
+// if seq contains a NaN the result is the first NaN. The Func and By
+// variants leave NaN handling to the comparison they are given.
+
+// isNaN reports whether x is a floating-point NaN, the only value that is
+// not equal to itself.
+func isNaN[T cmp.Ordered](x T) bool {
+	return x != x
+}
+
+// nanGreater reports whether x should replace cur as the running maximum.
+func nanGreater[T cmp.Ordered](x, cur T) bool {
+	return (isNaN(x) && !isNaN(cur)) || x > cur
+}
+
+// nanLess reports whether x should replace cur as the running minimum.
+func nanLess[T cmp.Ordered](x, cur T) bool {
+	return (isNaN(x) && !isNaN(cur)) || x < cur
+}
+
+// nanTied reports whether x ties with the running extreme cur.
+func nanTied[T cmp.Ordered](x, cur T) bool {
+	return x == cur || (isNaN(x) && isNaN(cur))
+}
+
+// MinMax returns the smallest and largest elements of seq, reading it
+// once. If seq is empty, ok is false.
+func MinMax[T cmp.Ordered](seq iter.Seq[T]) (min, max T, ok bool) {
+	for item := range seq {
+		if !ok {
+			min, max, ok = item, item, true
+			continue
+		}
+		if nanLess(item, min) {
+			min = item
+		}
+		if nanGreater(item, max) {
+			max = item
+		}
+	}
+	return
+}
+
+// MinMaxFunc is like MinMax but orders elements with cmp, which returns a
+// negative number when a < b, a positive number when a > b and zero
+// otherwise. When several elements are minimal or maximal, the first is
+// returned.
+func MinMaxFunc[T any](seq iter.Seq[T], cmp func(a, b T) int) (min, max T, ok bool) {
+	for item := range seq {
+		if !ok {
+			min, max, ok = item, item, true
+			continue
+		}
+		if cmp(item, min) < 0 {
+			min = item
+		}
+		if cmp(item, max) > 0 {
+			max = item
+		}
+	}
+	return
+}
+
+// ArgMax returns the position in seq of its largest element, or -1 if seq
+// is empty. When several elements are maximal, the first position is
+// returned.
+func ArgMax[T cmp.Ordered](seq iter.Seq[T]) int {
+	return argExtreme(seq, nanGreater[T])
+}
+
+// ArgMin returns the position in seq of its smallest element, or -1 if seq
+// is empty. When several elements are minimal, the first position is
+// returned.
+func ArgMin[T cmp.Ordered](seq iter.Seq[T]) int {
+	return argExtreme(seq, nanLess[T])
+}
+
+// ArgMaxFunc is like ArgMax but orders elements with a cmp-style function.
+func ArgMaxFunc[T any](seq iter.Seq[T], cmp func(a, b T) int) int {
+	return argExtreme(seq, func(x, cur T) bool { return cmp(x, cur) > 0 })
+}
+
+// ArgMinFunc is like ArgMin but orders elements with a cmp-style function.
+func ArgMinFunc[T any](seq iter.Seq[T], cmp func(a, b T) int) int {
+	return argExtreme(seq, func(x, cur T) bool { return cmp(x, cur) < 0 })
+}
+
+// argExtreme returns the position of the first element of seq that no
+// later element replaces, or -1 if seq is empty.
+func argExtreme[T any](seq iter.Seq[T], replaces func(x, cur T) bool) int {
+	var (
+		best T
+		at   = -1
+		i    int
+	)
+	for item := range seq {
+		if at < 0 || replaces(item, best) {
+			best, at = item, i
+		}
+		i++
+	}
+	return at
+}
+
+// MaxBy returns the element of seq with the largest key, as computed by
+// keyFn once per element. When several elements share the largest key, the
+// first is returned. If seq is empty, ok is false.
+func MaxBy[T any, K cmp.Ordered](seq iter.Seq[T], keyFn func(T) K) (max T, ok bool) {
+	var best K
+	for item := range seq {
+		if k := keyFn(item); !ok || nanGreater(k, best) {
+			max, best, ok = item, k, true
+		}
+	}
+	return
+}
+
+// MinBy returns the element of seq with the smallest key, as computed by
+// keyFn once per element. When several elements share the smallest key,
+// the first is returned. If seq is empty, ok is false.
+func MinBy[T any, K cmp.Ordered](seq iter.Seq[T], keyFn func(T) K) (min T, ok bool) {
+	var best K
+	for item := range seq {
+		if k := keyFn(item); !ok || nanLess(k, best) {
+			min, best, ok = item, k, true
+		}
+	}
+	return
+}
+
+// AllMax returns every element of seq equal to its largest element, in the
+// order they appear. It returns nil if seq is empty.
+func AllMax[T cmp.Ordered](seq iter.Seq[T]) []T {
+	return allExtreme(seq, func(x, cur T) int {
+		switch {
+		case nanGreater(x, cur):
+			return 1
+		case nanTied(x, cur):
+			return 0
+		}
+		return -1
+	})
+}
+
+// AllMin returns every element of seq equal to its smallest element, in
+// the order they appear. It returns nil if seq is empty.
+func AllMin[T cmp.Ordered](seq iter.Seq[T]) []T {
+	return allExtreme(seq, func(x, cur T) int {
+		switch {
+		case nanLess(x, cur):
+			return 1
+		case nanTied(x, cur):
+			return 0
+		}
+		return -1
+	})
+}
+
+// AllMaxFunc is like AllMax but orders elements with a cmp-style function.
+func AllMaxFunc[T any](seq iter.Seq[T], cmp func(a, b T) int) []T {
+	return allExtreme(seq, cmp)
+}
+
+// AllMinFunc is like AllMin but orders elements with a cmp-style function.
+func AllMinFunc[T any](seq iter.Seq[T], cmp func(a, b T) int) []T {
+	return allExtreme(seq, func(x, cur T) int { return -cmp(x, cur) })
+}
+
+// allExtreme collects the elements of seq that tie with the most extreme
+// one. rank returns a positive number when x is more extreme than cur and
+// zero when they tie.
+func allExtreme[T any](seq iter.Seq[T], rank func(x, cur T) int) []T {
+	var out []T
+	for item := range seq {
+		if len(out) == 0 {
+			out = append(out, item)
+			continue
+		}
+		switch r := rank(item, out[0]); {
+		case r > 0:
+			out = append(out[:0], item)
+		case r == 0:
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,219 @@
+package iters_test
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleMinMax() {
+	min, max, ok := iters.MinMax(slices.Values([]int{3, 1, 4, 1, 5}))
+	fmt.Println(min, max, ok)
+	// Output:
+	// 1 5 true
+}
+
+func ExampleArgMax() {
+	fmt.Println(iters.ArgMax(slices.Values([]int{3, 1, 4, 1, 5, 9, 2})))
+	fmt.Println(iters.ArgMax(slices.Values([]int(nil))))
+	// Output:
+	// 5
+	// -1
+}
+
+func ExampleMaxBy() {
+	words := slices.Values([]string{"go", "iterator", "seq", "sequence"})
+	longest, ok := iters.MaxBy(words, func(s string) int { return len(s) })
+	fmt.Println(longest, ok)
+	// Output:
+	// iterator true
+}
+
+func ExampleAllMax() {
+	fmt.Println(iters.AllMax(slices.Values([]int{2, 5, 1, 5, 3, 5})))
+	// Output:
+	// [5 5 5]
+}
+
+func TestMinMaxMatchesMinAndMax(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(-50, 50), 20), func(s []int) bool {
+		min, max, ok := iters.MinMax(slices.Values(s))
+		wantMin, _ := iters.Min(slices.Values(s))
+		wantMax, _ := iters.Max(slices.Values(s))
+		return ok == (len(s) > 0) && min == wantMin && max == wantMax
+	})
+}
+
+func TestMinMaxFunc(t *testing.T) {
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+
+	min, max, ok := iters.MinMaxFunc(slices.Values([]string{"bb", "a", "ccc", "d", "eee"}), byLen)
+	if !ok || min != "a" || max != "ccc" {
+		t.Fatalf("MinMaxFunc: expected (a,ccc,true), got (%v,%v,%v)", min, max, ok)
+	}
+
+	if _, _, ok := iters.MinMaxFunc(slices.Values([]string(nil)), byLen); ok {
+		t.Fatalf("MinMaxFunc: expected ok=false for an empty sequence")
+	}
+}
+
+func TestArgMinArgMax(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(-5, 5), 20), func(s []int) bool {
+		maxAt := iters.ArgMax(slices.Values(s))
+		minAt := iters.ArgMin(slices.Values(s))
+		if len(s) == 0 {
+			return maxAt == -1 && minAt == -1
+		}
+		// The first position holding the extreme value.
+		return maxAt == slices.Index(s, slices.Max(s)) && minAt == slices.Index(s, slices.Min(s))
+	})
+}
+
+func TestArgMinArgMaxFunc(t *testing.T) {
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	words := slices.Values([]string{"bb", "a", "ccc", "d", "eee"})
+
+	if got := iters.ArgMaxFunc(words, byLen); got != 2 {
+		t.Fatalf("ArgMaxFunc: expected 2, got %d", got)
+	}
+	if got := iters.ArgMinFunc(words, byLen); got != 1 {
+		t.Fatalf("ArgMinFunc: expected 1, got %d", got)
+	}
+	if got := iters.ArgMaxFunc(slices.Values([]string(nil)), byLen); got != -1 {
+		t.Fatalf("ArgMaxFunc: expected -1, got %d", got)
+	}
+}
+
+func TestMaxByMinBy(t *testing.T) {
+	type player struct {
+		Name  string
+		Score int
+	}
+	score := func(p player) int { return p.Score }
+
+	players := slices.Values([]player{{"ann", 3}, {"bob", 7}, {"cid", 7}, {"dee", 1}, {"eve", 1}})
+
+	max, ok := iters.MaxBy(players, score)
+	if !ok || max.Name != "bob" {
+		t.Fatalf("MaxBy: expected bob, got (%v,%v)", max, ok)
+	}
+	min, ok := iters.MinBy(players, score)
+	if !ok || min.Name != "dee" {
+		t.Fatalf("MinBy: expected dee, got (%v,%v)", min, ok)
+	}
+	if _, ok := iters.MaxBy(slices.Values([]player(nil)), score); ok {
+		t.Fatalf("MaxBy: expected ok=false for an empty sequence")
+	}
+}
+
+func TestMaxByCallsKeyOncePerElement(t *testing.T) {
+	calls := 0
+	key := func(n int) int { calls++; return -n }
+
+	got, _ := iters.MaxBy(slices.Values([]int{4, 2, 8, 6}), key)
+	if got != 2 || calls != 4 {
+		t.Fatalf("MaxBy: expected 2 with 4 key calls, got %d with %d", got, calls)
+	}
+}
+
+func TestAllMaxAllMin(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(0, 3), 20), func(s []int) bool {
+		allMax := iters.AllMax(slices.Values(s))
+		allMin := iters.AllMin(slices.Values(s))
+		if len(s) == 0 {
+			return allMax == nil && allMin == nil
+		}
+		countOf := func(v int) int {
+			n := 0
+			for _, x := range s {
+				if x == v {
+					n++
+				}
+			}
+			return n
+		}
+		return len(allMax) == countOf(slices.Max(s)) && allMax[0] == slices.Max(s) &&
+			len(allMin) == countOf(slices.Min(s)) && allMin[0] == slices.Min(s)
+	})
+}
+
+func TestAllMaxFuncKeepsOrder(t *testing.T) {
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	words := slices.Values([]string{"a", "bb", "cc", "d", "ee", "f"})
+
+	if got := iters.AllMaxFunc(words, byLen); !slices.Equal(got, []string{"bb", "cc", "ee"}) {
+		t.Fatalf("AllMaxFunc: expected [bb cc ee], got %v", got)
+	}
+	if got := iters.AllMinFunc(words, byLen); !slices.Equal(got, []string{"a", "d", "f"}) {
+		t.Fatalf("AllMinFunc: expected [a d f], got %v", got)
+	}
+}
+
+func TestExtremaNaN(t *testing.T) {
+	nan := math.NaN()
+
+	// Wherever the NaN appears, it is the result, as with slices.Max and
+	// slices.Min.
+	for _, input := range [][]float64{
+		{nan, 1, 3, 2},
+		{1, nan, 3, 2},
+		{1, 3, 2, nan},
+	} {
+		name := strings.Trim(fmt.Sprint(input), "[]")
+		t.Run(name, func(t *testing.T) {
+			seq := slices.Values(input)
+			nanAt := slices.IndexFunc(input, math.IsNaN)
+
+			if got, _ := iters.Max(seq); !math.IsNaN(got) {
+				t.Fatalf("Max: expected NaN, got %v", got)
+			}
+			if got, _ := iters.Min(seq); !math.IsNaN(got) {
+				t.Fatalf("Min: expected NaN, got %v", got)
+			}
+			if min, max, _ := iters.MinMax(seq); !math.IsNaN(min) || !math.IsNaN(max) {
+				t.Fatalf("MinMax: expected (NaN,NaN), got (%v,%v)", min, max)
+			}
+			if got := iters.ArgMax(seq); got != nanAt {
+				t.Fatalf("ArgMax: expected %d, got %d", nanAt, got)
+			}
+			if got := iters.ArgMin(seq); got != nanAt {
+				t.Fatalf("ArgMin: expected %d, got %d", nanAt, got)
+			}
+			if got := iters.AllMax(seq); len(got) != 1 || !math.IsNaN(got[0]) {
+				t.Fatalf("AllMax: expected [NaN], got %v", got)
+			}
+			if k, v, _ := iters.Max2(iters.Swap(slices.All(input))); !math.IsNaN(k) || v != nanAt {
+				t.Fatalf("Max2: expected (NaN,%d), got (%v,%v)", nanAt, k, v)
+			}
+			if k, v, _ := iters.Min2(iters.Swap(slices.All(input))); !math.IsNaN(k) || v != nanAt {
+				t.Fatalf("Min2: expected (NaN,%d), got (%v,%v)", nanAt, k, v)
+			}
+		})
+	}
+
+	// The first of several NaNs is reported, and all of them tie.
+	input := []float64{1, nan, 2, nan}
+	if got := iters.ArgMax(slices.Values(input)); got != 1 {
+		t.Fatalf("ArgMax: expected 1, got %d", got)
+	}
+	if got := iters.AllMin(slices.Values(input)); len(got) != 2 {
+		t.Fatalf("AllMin: expected two NaNs, got %v", got)
+	}
+}
+
+func TestMinMaxFloatsWithoutNaN(t *testing.T) {
+	gen.ForAll(t, gen.Slice(func(r *rand.Rand) float64 { return r.NormFloat64() }, 20), func(s []float64) bool {
+		min, max, ok := iters.MinMax(slices.Values(s))
+		if len(s) == 0 {
+			return !ok
+		}
+		return min == slices.Min(s) && max == slices.Max(s)
+	})
+}
@@ -0,0 +1,91 @@
+package iters
+
+import "iter"
+
+// FlatMap returns a sequence of the elements of fn(item) for each element
+// of seq, in order. Each inner sequence is produced only when the consumer
+// reaches it, and stopping the iteration stops the current inner sequence.
+func FlatMap[T, R any](seq iter.Seq[T], fn func(T) iter.Seq[R]) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for item := range seq {
+			for r := range fn(item) {
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten returns a sequence of the elements of each sequence in seqs, in
+// order. It is the lazy counterpart to [Concat] for a sequence of
+// sequences.
+func Flatten[T any](seqs iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for seq := range seqs {
+			for item := range seq {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlattenSlices returns a sequence of the elements of each slice in seq, in
+// order. It reverses [Chunk]: FlattenSlices(Chunk(seq, n)) yields the
+// elements of seq.
+func FlattenSlices[T any](seq iter.Seq[[]T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for s := range seq {
+			for _, item := range s {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMap2 is the keyed companion to FlatMap; it yields the pairs of
+// fn(k, v) for each pair of seq2.
+func FlatMap2[K1, V1, K2, V2 any](seq2 iter.Seq2[K1, V1], fn func(K1, V1) iter.Seq2[K2, V2]) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range seq2 {
+			for k2, v2 := range fn(k, v) {
+				if !yield(k2, v2) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten2 is the keyed companion to Flatten; it yields the pairs of each
+// sequence in seqs, in order.
+func Flatten2[K, V any](seqs iter.Seq[iter.Seq2[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for seq2 := range seqs {
+			for k, v := range seq2 {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlattenSlices2 reverses [Chunk2], yielding the key/value pairs held in
+// each pair of slices. When a key slice and its value slice differ in
+// length, the extra elements of the longer one are dropped.
+func FlattenSlices2[K, V any](seq2 iter.Seq2[[]K, []V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for keys, values := range seq2 {
+			for i := range min(len(keys), len(values)) {
+				if !yield(keys[i], values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
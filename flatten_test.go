@@ -0,0 +1,118 @@
+package iters_test
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleFlatMap() {
+	words := slices.Values([]string{"go", "iter"})
+	letters := iters.FlatMap(words, func(w string) iter.Seq[string] {
+		return slices.Values(strings.Split(w, ""))
+	})
+	fmt.Println(slices.Collect(letters))
+	// Output:
+	// [g o i t e r]
+}
+
+func ExampleFlattenSlices() {
+	chunks := iters.Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2)
+	fmt.Println(slices.Collect(iters.FlattenSlices(chunks)))
+	// Output:
+	// [1 2 3 4 5]
+}
+
+type flattenTableTest[T comparable] struct {
+	name     string
+	input    [][]T
+	expected []T
+}
+
+func (test flattenTableTest[T]) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		seqs := iters.Map(slices.Values(test.input), slices.Values[[]T])
+		if got := slices.Collect(iters.Flatten(seqs)); !slices.Equal(got, test.expected) {
+			t.Fatalf("Flatten: expected %v, got %v", test.expected, got)
+		}
+		if got := slices.Collect(iters.FlattenSlices(slices.Values(test.input))); !slices.Equal(got, test.expected) {
+			t.Fatalf("FlattenSlices: expected %v, got %v", test.expected, got)
+		}
+		iterstest.CheckSeq(t, iters.Flatten(seqs))
+	})
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []runnableTest{
+		flattenTableTest[int]{name: "nested", input: [][]int{{1, 2}, {}, {3}}, expected: []int{1, 2, 3}},
+		flattenTableTest[string]{name: "empty", input: nil, expected: nil},
+		flattenTableTest[string]{name: "empty inner", input: [][]string{nil, {}}, expected: nil},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestFlatMapStopsInnerSequence(t *testing.T) {
+	outer, outerCount := iterstest.Counting(iterstest.Naturals())
+	var inner *iterstest.Counter
+	seq := iters.FlatMap(outer, func(n int) iter.Seq[int] {
+		var s iter.Seq[int]
+		s, inner = iterstest.Counting(iterstest.Naturals())
+		return s
+	})
+
+	if got := slices.Collect(iters.Limit(seq, 3)); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Fatalf("FlatMap: expected [0 1 2], got %v", got)
+	}
+	if outerCount.Pulled() != 1 || inner.Pulled() != 3 || inner.Stops() != 1 {
+		t.Fatalf("FlatMap: expected one inner sequence stopped after 3, got outer %d, inner %d", outerCount.Pulled(), inner.Pulled())
+	}
+}
+
+func TestFlattenSlicesInvertsChunk(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(0, 9), 30), func(s []int) bool {
+		for size := 1; size <= 5; size++ {
+			if !slices.Equal(slices.Collect(iters.FlattenSlices(iters.Chunk(slices.Values(s), size))), s) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestFlatten2(t *testing.T) {
+	pairs := maps.Collect(iters.FlatMap2(slices.All([]string{"a", "b"}), func(i int, s string) iter.Seq2[string, int] {
+		return func(yield func(string, int) bool) {
+			_ = yield(s, i) && yield(strings.ToUpper(s), i)
+		}
+	}))
+	if !maps.Equal(pairs, map[string]int{"a": 0, "A": 0, "b": 1, "B": 1}) {
+		t.Fatalf("FlatMap2: unexpected %v", pairs)
+	}
+
+	nested := slices.Values([]iter.Seq2[int, string]{slices.All([]string{"x"}), slices.All([]string{"y", "z"})})
+	var values []string
+	for _, v := range iters.Flatten2(nested) {
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []string{"x", "y", "z"}) {
+		t.Fatalf("Flatten2: expected [x y z], got %v", values)
+	}
+
+	var keys []string
+	for k := range iters.FlattenSlices2(iters.Chunk2(maps.All(map[string]int{"a": 1, "b": 2, "c": 3}), 2)) {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("FlattenSlices2: expected [a b c], got %v", keys)
+	}
+}
@@ -0,0 +1,56 @@
+package iters
+
+import (
+	"iter"
+	"slices"
+)
+
+// Frequencies returns the number of times each distinct element occurs in
+// seq. It returns an empty map if seq is empty.
+func Frequencies[T comparable](seq iter.Seq[T]) map[T]int {
+	counts := make(map[T]int)
+	for item := range seq {
+		counts[item]++
+	}
+	return counts
+}
+
+// MostCommon returns the n most frequent elements of seq paired with their
+// counts, most frequent first. Elements with the same count are ordered by
+// their first occurrence in seq. If seq has fewer than n distinct elements,
+// all of them are returned, as they are when n is negative.
+func MostCommon[T comparable](seq iter.Seq[T], n int) []Pair[T, int] {
+	// Record each element's position in order of first occurrence, so ties
+	// can be broken deterministically.
+	index := make(map[T]int)
+	var counts []Pair[T, int]
+	for item := range seq {
+		i, ok := index[item]
+		if !ok {
+			i = len(counts)
+			index[item] = i
+			counts = append(counts, Pair[T, int]{Key: item})
+		}
+		counts[i].Value++
+	}
+
+	slices.SortStableFunc(counts, func(a, b Pair[T, int]) int {
+		return b.Value - a.Value
+	})
+	if n >= 0 && n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// Frequencies2 returns the number of times each distinct pair occurs in
+// seq2.
+func Frequencies2[K, V comparable](seq2 iter.Seq2[K, V]) map[Pair[K, V]]int {
+	return Frequencies(Pairs(seq2))
+}
+
+// MostCommon2 returns the n most frequent pairs of seq2 with their counts,
+// as MostCommon does.
+func MostCommon2[K, V comparable](seq2 iter.Seq2[K, V], n int) []Pair[Pair[K, V], int] {
+	return MostCommon(Pairs(seq2), n)
+}
@@ -0,0 +1,81 @@
+package iters_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleMostCommon() {
+	words := slices.Values(strings.Fields("the cat and the dog and the bird"))
+	for _, p := range iters.MostCommon(words, 2) {
+		fmt.Println(p.Key, p.Value)
+	}
+	// Output:
+	// the 3
+	// and 2
+}
+
+func TestFrequencies(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(0, 4), 20), func(s []int) bool {
+		counts := iters.Frequencies(slices.Values(s))
+		total := 0
+		for v, n := range counts {
+			if n != iters.CountFunc(slices.Values(s), func(x int) bool { return x == v }) {
+				return false
+			}
+			total += n
+		}
+		return total == len(s)
+	})
+
+	if got := iters.Frequencies(slices.Values([]string(nil))); got == nil || len(got) != 0 {
+		t.Fatalf("Frequencies: expected an empty map, got %v", got)
+	}
+}
+
+func TestMostCommon(t *testing.T) {
+	letters := func() []string { return strings.Split("cabbcad", "") }
+
+	tests := []struct {
+		name string
+		n    int
+		want []iters.Pair[string, int]
+	}{
+		{"top one", 1, []iters.Pair[string, int]{{"c", 2}}},
+		// Ties keep the order of first occurrence.
+		{"ties", 3, []iters.Pair[string, int]{{"c", 2}, {"a", 2}, {"b", 2}}},
+		{"more than distinct", 10, []iters.Pair[string, int]{{"c", 2}, {"a", 2}, {"b", 2}, {"d", 1}}},
+		{"negative", -1, []iters.Pair[string, int]{{"c", 2}, {"a", 2}, {"b", 2}, {"d", 1}}},
+		{"zero", 0, []iters.Pair[string, int]{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := iters.MostCommon(slices.Values(letters()), test.n)
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("MostCommon: expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFrequencies2(t *testing.T) {
+	seq2 := iters.Unpairs(slices.Values([]iters.Pair[string, int]{
+		{"a", 1}, {"b", 2}, {"a", 1}, {"a", 2},
+	}))
+
+	want := map[iters.Pair[string, int]]int{{"a", 1}: 2, {"b", 2}: 1, {"a", 2}: 1}
+	if got := iters.Frequencies2(seq2); !maps.Equal(got, want) {
+		t.Fatalf("Frequencies2: expected %v, got %v", want, got)
+	}
+
+	top := iters.MostCommon2(seq2, 1)
+	if len(top) != 1 || top[0].Key != (iters.Pair[string, int]{"a", 1}) || top[0].Value != 2 {
+		t.Fatalf("MostCommon2: expected [{{a 1} 2}], got %v", top)
+	}
+}
@@ -0,0 +1,22 @@
+package iters
+
+import "iter"
+
+// Index returns the position of the first occurrence of value in seq, or
+// -1 if value is not present. It stops reading seq at the match.
+func Index[V comparable](seq iter.Seq[V], value V) int {
+	return IndexFunc(seq, func(item V) bool { return item == value })
+}
+
+// IndexFunc returns the position of the first element of seq that
+// satisfies pred, or -1 if none does. It stops reading seq at the match.
+func IndexFunc[V any](seq iter.Seq[V], pred Predicate[V]) int {
+	i := 0
+	for item := range seq {
+		if pred(item) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
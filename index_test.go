@@ -0,0 +1,38 @@
+package iters_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleIndex() {
+	fmt.Println(iters.Index(slices.Values([]string{"a", "b", "c", "b"}), "b"))
+	fmt.Println(iters.Index(slices.Values([]string{"a", "b"}), "z"))
+	// Output:
+	// 1
+	// -1
+}
+
+func TestIndexMatchesSlices(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(0, 5), 12), func(s []int) bool {
+		isBig := func(n int) bool { return n > 3 }
+		return iters.Index(slices.Values(s), 2) == slices.Index(s, 2) &&
+			iters.IndexFunc(slices.Values(s), isBig) == slices.IndexFunc(s, isBig)
+	})
+}
+
+func TestIndexStopsAtMatch(t *testing.T) {
+	src, c := iterstest.Counting(iterstest.Naturals())
+
+	if got := iters.IndexFunc(src, func(n int) bool { return n*n > 50 }); got != 8 {
+		t.Fatalf("IndexFunc: expected 8, got %d", got)
+	}
+	if c.Pulled() != 9 || c.Stops() != 1 {
+		t.Fatalf("IndexFunc: expected 9 pulled and 1 stop, got %d and %d", c.Pulled(), c.Stops())
+	}
+}
@@ -0,0 +1,157 @@
+package iters
+
+import (
+	"expvar"
+	"iter"
+	"sync"
+	"time"
+)
+
+// Tap returns a sequence that yields every element of seq unchanged after
+// passing it to fn. It is useful for observing a pipeline without changing
+// it, for example to log or count the elements reaching a stage.
+func Tap[T any](seq iter.Seq[T], fn func(T)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			fn(item)
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Tap2 is the keyed companion to Tap; it passes each pair from seq2 to fn
+// before yielding it.
+func Tap2[K, V any](seq2 iter.Seq2[K, V], fn func(K, V)) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq2 {
+			fn(k, v)
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// StageStats summarizes a single iteration of an instrumented stage.
+type StageStats struct {
+	// In is the number of elements the stage received from its input.
+	In int64
+	// Out is the number of elements the stage yielded.
+	Out int64
+	// Duration is the time spent inside the stage, including the callbacks
+	// passed to it, but excluding the time spent producing its input and
+	// consuming its output.
+	Duration time.Duration
+	// Stopped reports whether the consumer stopped the stage early.
+	Stopped bool
+}
+
+// Recorder receives the statistics of instrumented stages. RecordStage is
+// called once each time an instrumented stage finishes an iteration, and
+// may be called concurrently.
+type Recorder interface {
+	RecordStage(name string, stats StageStats)
+}
+
+// RecorderFunc adapts an ordinary function to the Recorder interface.
+type RecorderFunc func(name string, stats StageStats)
+
+// RecordStage calls f(name, stats).
+func (f RecorderFunc) RecordStage(name string, stats StageStats) {
+	f(name, stats)
+}
+
+// Instrument applies stage to seq and reports the elements flowing into and
+// out of it, the time spent in it, and whether the consumer stopped early,
+// to rec under name:
+//
+//	evens := iters.Instrument(numbers, "evens", rec, func(s iter.Seq[int]) iter.Seq[int] {
+//		return iters.Filter(s, isEven)
+//	})
+//
+// When rec is nil, Instrument returns stage(seq) itself, so instrumentation
+// that is switched off adds no overhead.
+func Instrument[T, R any](seq iter.Seq[T], name string, rec Recorder, stage func(iter.Seq[T]) iter.Seq[R]) iter.Seq[R] {
+	if rec == nil {
+		return stage(seq)
+	}
+
+	return func(yield func(R) bool) {
+		var (
+			stats   StageStats
+			entered time.Time
+		)
+		// Control passes into the stage when it receives an element or when
+		// the consumer returns from handling one, and leaves it when the
+		// stage asks for its next input or yields an output.
+		enter := func() { entered = time.Now() }
+		leave := func() { stats.Duration += time.Since(entered) }
+
+		input := func(yield func(T) bool) {
+			leave()
+			defer enter()
+			for item := range seq {
+				stats.In++
+				enter()
+				ok := yield(item)
+				leave()
+				if !ok {
+					return
+				}
+			}
+		}
+
+		enter()
+		for item := range stage(input) {
+			stats.Out++
+			leave()
+			ok := yield(item)
+			enter()
+			if !ok {
+				stats.Stopped = true
+				break
+			}
+		}
+		leave()
+
+		rec.RecordStage(name, stats)
+	}
+}
+
+// ExpvarRecorder is a Recorder that accumulates stage statistics in an
+// [expvar.Map]. Each stage is stored under its name as a nested map with
+// the integer counters "runs", "stops", "in", "out" and "nanoseconds".
+type ExpvarRecorder struct {
+	mu sync.Mutex
+	m  *expvar.Map
+}
+
+// NewExpvarRecorder returns an ExpvarRecorder that stores statistics in m.
+// The caller is responsible for publishing m, typically with
+// expvar.NewMap.
+func NewExpvarRecorder(m *expvar.Map) *ExpvarRecorder {
+	return &ExpvarRecorder{m: m}
+}
+
+// RecordStage implements Recorder.
+func (r *ExpvarRecorder) RecordStage(name string, stats StageStats) {
+	r.mu.Lock()
+	stage, ok := r.m.Get(name).(*expvar.Map)
+	if !ok {
+		stage = new(expvar.Map)
+		r.m.Set(name, stage)
+	}
+	r.mu.Unlock()
+
+	stage.Add("runs", 1)
+	if stats.Stopped {
+		stage.Add("stops", 1)
+	} else {
+		stage.Add("stops", 0)
+	}
+	stage.Add("in", stats.In)
+	stage.Add("out", stats.Out)
+	stage.Add("nanoseconds", stats.Duration.Nanoseconds())
+}
@@ -0,0 +1,186 @@
+package iters_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/picatz/iters"
+)
+
+func ExampleTap() {
+	seen := 0
+	evens := iters.Filter(
+		iters.Tap(slices.Values([]int{1, 2, 3, 4}), func(int) { seen++ }),
+		func(n int) bool { return n%2 == 0 },
+	)
+
+	fmt.Println(slices.Collect(evens), seen)
+	// Output:
+	// [2 4] 4
+}
+
+func ExampleInstrument() {
+	rec := iters.RecorderFunc(func(name string, stats iters.StageStats) {
+		fmt.Printf("%s: in=%d out=%d stopped=%t\n", name, stats.In, stats.Out, stats.Stopped)
+	})
+
+	numbers := slices.Values([]int{1, 2, 3, 4, 5, 6})
+	evens := iters.Instrument(numbers, "evens", rec, func(s iter.Seq[int]) iter.Seq[int] {
+		return iters.Filter(s, func(n int) bool { return n%2 == 0 })
+	})
+
+	for n := range evens {
+		if n == 4 {
+			break
+		}
+	}
+	// Output:
+	// evens: in=4 out=2 stopped=true
+}
+
+func TestTap2(t *testing.T) {
+	var keys []string
+	seq := iters.Tap2(iters.Zip(slices.Values([]string{"a", "b", "c"}), slices.Values([]int{1, 2, 3})), func(k string, _ int) {
+		keys = append(keys, k)
+	})
+
+	for range iters.Limit2(seq, 2) {
+	}
+	if want := []string{"a", "b"}; !slices.Equal(keys, want) {
+		t.Fatalf("expected %v, got %v", want, keys)
+	}
+}
+
+// statsRecorder collects every recorded stage.
+type statsRecorder struct {
+	mu    sync.Mutex
+	names []string
+	stats []iters.StageStats
+}
+
+func (r *statsRecorder) RecordStage(name string, stats iters.StageStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, name)
+	r.stats = append(r.stats, stats)
+}
+
+func TestInstrumentPipeline(t *testing.T) {
+	rec := &statsRecorder{}
+	numbers := slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	odd := iters.Instrument(numbers, "odd", rec, func(s iter.Seq[int]) iter.Seq[int] {
+		return iters.Filter(s, func(n int) bool { return n%2 == 1 })
+	})
+	first2 := iters.Instrument(odd, "first2", rec, func(s iter.Seq[int]) iter.Seq[int] {
+		return iters.Limit(s, 2)
+	})
+
+	if got, want := slices.Collect(first2), []int{1, 3}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// The inner stage finishes first, stopped by the limit.
+	if want := []string{"odd", "first2"}; !slices.Equal(rec.names, want) {
+		t.Fatalf("expected stages %v, got %v", want, rec.names)
+	}
+	if s := rec.stats[0]; s.In != 3 || s.Out != 2 || !s.Stopped {
+		t.Fatalf("odd: unexpected stats %+v", s)
+	}
+	if s := rec.stats[1]; s.In != 2 || s.Out != 2 || s.Stopped {
+		t.Fatalf("first2: unexpected stats %+v", s)
+	}
+}
+
+func TestInstrumentDuration(t *testing.T) {
+	const delay = 5 * time.Millisecond
+
+	slowSource := func(yield func(int) bool) {
+		for i := range 3 {
+			time.Sleep(delay)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	rec := &statsRecorder{}
+	slowStage := iters.Instrument(slowSource, "slow", rec, func(s iter.Seq[int]) iter.Seq[int] {
+		return iters.Filter(s, func(int) bool {
+			time.Sleep(2 * delay)
+			return true
+		})
+	})
+	for range slowStage {
+		time.Sleep(delay)
+	}
+
+	got := rec.stats[0].Duration
+	if got < 6*delay {
+		t.Fatalf("expected at least %v spent in the stage, got %v", 6*delay, got)
+	}
+	// Time spent in the source and the consumer (6 delays) is excluded.
+	if got >= 12*delay {
+		t.Fatalf("expected time outside the stage to be excluded, got %v", got)
+	}
+}
+
+func TestInstrumentNilRecorder(t *testing.T) {
+	calls := 0
+	stage := func(s iter.Seq[int]) iter.Seq[int] {
+		calls++
+		return iters.Limit(s, 1)
+	}
+
+	seq := iters.Instrument(slices.Values([]int{1, 2}), "noop", nil, stage)
+	if calls != 1 {
+		t.Fatalf("expected the stage to be applied directly, got %d calls", calls)
+	}
+	if got := slices.Collect(seq); !slices.Equal(got, []int{1}) {
+		t.Fatalf("expected [1], got %v", got)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for range iters.Instrument(slices.Values([]int{1, 2}), "noop", nil, stage) {
+		}
+	})
+	direct := testing.AllocsPerRun(100, func() {
+		for range stage(slices.Values([]int{1, 2})) {
+		}
+	})
+	if allocs != direct {
+		t.Fatalf("expected no added allocations, got %v vs %v", allocs, direct)
+	}
+}
+
+func TestExpvarRecorder(t *testing.T) {
+	m := new(expvar.Map)
+	rec := iters.NewExpvarRecorder(m)
+
+	for range 2 {
+		seq := iters.Instrument(slices.Values([]int{1, 2, 3}), "stage", rec, func(s iter.Seq[int]) iter.Seq[int] {
+			return iters.Filter(s, func(n int) bool { return n > 1 })
+		})
+		for range seq {
+			break
+		}
+	}
+
+	var got map[string]map[string]int64
+	if err := json.Unmarshal([]byte(m.String()), &got); err != nil {
+		t.Fatalf("unexpected error %v decoding %s", err, m.String())
+	}
+	stage := got["stage"]
+	if stage["runs"] != 2 || stage["stops"] != 2 || stage["in"] != 4 || stage["out"] != 2 {
+		t.Fatalf("unexpected counters %v", stage)
+	}
+	if _, ok := stage["nanoseconds"]; !ok {
+		t.Fatalf("expected a nanoseconds counter, got %v", stage)
+	}
+}
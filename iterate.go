@@ -0,0 +1,51 @@
+package iters
+
+import "iter"
+
+// Iterate returns a sequence that starts with seed and then yields the
+// results of repeatedly applying fn to the previous value. Generation
+// stops when fn reports false; the value returned alongside false is not
+// yielded.
+func Iterate[T any](seed T, fn func(T) (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v, ok := seed, true; ok; v, ok = fn(v) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Cycle returns an infinite sequence that yields the elements of seq and
+// then repeats them in order until the consumer stops. seq is ranged over
+// once; its elements are cached during the first pass and replayed from
+// memory afterwards, so seq must be finite. If seq is empty, nothing is
+// produced.
+func Cycle[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var cache []T
+		for item := range seq {
+			cache = append(cache, item)
+			if !yield(item) {
+				return
+			}
+		}
+		if len(cache) == 0 {
+			return
+		}
+		for {
+			for _, item := range cache {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Cycle2 is the keyed companion to Cycle; it yields the pairs of seq2
+// forever, caching them during the first pass. If seq2 is empty, nothing is
+// produced.
+func Cycle2[K, V any](seq2 iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return Unpairs(Cycle(Pairs(seq2)))
+}
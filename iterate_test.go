@@ -0,0 +1,82 @@
+package iters_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleIterate() {
+	collatz := iters.Iterate(6, func(n int) (int, bool) {
+		if n == 1 {
+			return 0, false
+		}
+		if n%2 == 0 {
+			return n / 2, true
+		}
+		return 3*n + 1, true
+	})
+	fmt.Println(slices.Collect(collatz))
+	// Output:
+	// [6 3 10 5 16 8 4 2 1]
+}
+
+func ExampleCycle() {
+	fmt.Println(slices.Collect(iters.Limit(iters.Cycle(slices.Values([]string{"a", "b"})), 5)))
+	// Output:
+	// [a b a b a]
+}
+
+func TestIterateMatchesLoop(t *testing.T) {
+	gen.ForAll(t, gen.Int(1, 1<<20), func(seed int) bool {
+		halve := func(n int) (int, bool) { return n / 2, n > 1 }
+
+		var want []int
+		for n := seed; ; n /= 2 {
+			want = append(want, n)
+			if n <= 1 {
+				break
+			}
+		}
+		return slices.Equal(slices.Collect(iters.Iterate(seed, halve)), want)
+	})
+
+	iterstest.CheckSeq(t, iters.Iterate(0, func(n int) (int, bool) { return n + 1, true }))
+}
+
+func TestCycleMatchesLoop(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(0, 9), 8), func(s []int) bool {
+		const n = 25
+		var want []int
+		for i := 0; len(s) > 0 && i < n; i++ {
+			want = append(want, s[i%len(s)])
+		}
+		return slices.Equal(slices.Collect(iters.Limit(iters.Cycle(slices.Values(s)), n)), want)
+	})
+}
+
+func TestCycleRangesSourceOnce(t *testing.T) {
+	src, c := iterstest.Counting(slices.Values([]int{1, 2, 3}))
+	for range iters.Limit(iters.Cycle(src), 10) {
+	}
+	if c.Iterations() != 1 || c.Pulled() != 3 {
+		t.Fatalf("Cycle: expected one pass pulling 3, got %d iterations pulling %d", c.Iterations(), c.Pulled())
+	}
+
+	if got := slices.Collect(iters.Cycle(slices.Values([]int(nil)))); len(got) != 0 {
+		t.Fatalf("Cycle: expected an empty cycle, got %v", got)
+	}
+	iterstest.CheckSeq(t, iters.Cycle(slices.Values([]int{1, 2})))
+}
+
+func TestCycle2(t *testing.T) {
+	src, c := iterstest.Counting2(slices.All([]string{"a", "b"}))
+	got := slices.Collect(iters.Keys(iters.Limit2(iters.Cycle2(src), 5)))
+	if !slices.Equal(got, []int{0, 1, 0, 1, 0}) || c.Iterations() != 1 {
+		t.Fatalf("Cycle2: expected [0 1 0 1 0] from one pass, got %v from %d", got, c.Iterations())
+	}
+}
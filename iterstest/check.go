@@ -0,0 +1,140 @@
+package iterstest
+
+import (
+	"fmt"
+	"iter"
+	"sync/atomic"
+	"testing"
+)
+
+// MaxChecked bounds how many elements CheckSeq and CheckSeq2 read from a
+// sequence, so that infinite sequences can be checked.
+const MaxChecked = 64
+
+// yieldGuard detects misuse of a yield function: calls made after it
+// returned false, and calls made while a previous call is still running.
+type yieldGuard struct {
+	t        testing.TB
+	desc     string
+	active   atomic.Int32
+	stopped  atomic.Bool
+	returned atomic.Bool
+	calls    atomic.Int64
+	failed   atomic.Bool
+}
+
+func (g *yieldGuard) errorf(format string, args ...any) {
+	g.t.Helper()
+	// Report each kind of misuse once per iteration rather than once per
+	// call, which could be unbounded.
+	if g.failed.CompareAndSwap(false, true) {
+		g.t.Errorf(g.desc+": "+format, args...)
+	}
+}
+
+// enter is called at the start of each yield call and reports whether the
+// call is allowed to proceed.
+func (g *yieldGuard) enter() bool {
+	g.t.Helper()
+	n := g.calls.Add(1)
+	switch {
+	case g.returned.Load():
+		g.errorf("yield called (call %d) after the sequence function returned", n)
+		return false
+	case g.stopped.Load():
+		g.errorf("yield called (call %d) after it returned false", n)
+		return false
+	case g.active.Add(1) != 1:
+		g.active.Add(-1)
+		g.errorf("yield called re-entrantly (call %d) while a previous call was still running", n)
+		return false
+	}
+	return true
+}
+
+func (g *yieldGuard) exit(result bool) bool {
+	g.active.Add(-1)
+	if !result {
+		g.stopped.Store(true)
+	}
+	return result
+}
+
+// CheckSeq iterates seq repeatedly and reports, through t, any violation of
+// the iter.Seq contract: calling yield again after it returned false, or
+// calling yield while a previous call has not returned, for example from
+// another goroutine. It iterates seq once to completion and then once for
+// each possible stopping point, reading at most MaxChecked elements each
+// time, so seq must be safe to iterate more than once.
+func CheckSeq[T any](t testing.TB, seq iter.Seq[T]) {
+	t.Helper()
+
+	n := 0
+	runCheck(t, "full iteration", func(g *yieldGuard) {
+		seq(func(T) bool {
+			if !g.enter() {
+				return false
+			}
+			n++
+			return g.exit(n < MaxChecked)
+		})
+	})
+
+	for stop := range min(n, MaxChecked) + 1 {
+		runCheck(t, stopDesc(stop), func(g *yieldGuard) {
+			seen := 0
+			seq(func(T) bool {
+				if !g.enter() {
+					return false
+				}
+				seen++
+				return g.exit(seen <= stop)
+			})
+		})
+	}
+}
+
+// CheckSeq2 is the keyed companion to CheckSeq.
+func CheckSeq2[K, V any](t testing.TB, seq2 iter.Seq2[K, V]) {
+	t.Helper()
+
+	n := 0
+	runCheck(t, "full iteration", func(g *yieldGuard) {
+		seq2(func(K, V) bool {
+			if !g.enter() {
+				return false
+			}
+			n++
+			return g.exit(n < MaxChecked)
+		})
+	})
+
+	for stop := range min(n, MaxChecked) + 1 {
+		runCheck(t, stopDesc(stop), func(g *yieldGuard) {
+			seen := 0
+			seq2(func(K, V) bool {
+				if !g.enter() {
+					return false
+				}
+				seen++
+				return g.exit(seen <= stop)
+			})
+		})
+	}
+}
+
+func stopDesc(stop int) string {
+	if stop == 0 {
+		return "stopping at the first element"
+	}
+	return fmt.Sprintf("stopping after %d elements", stop)
+}
+
+// runCheck runs a single iteration under a fresh guard.
+func runCheck(t testing.TB, desc string, iterate func(*yieldGuard)) {
+	t.Helper()
+
+	g := &yieldGuard{t: t, desc: desc}
+	iterate(g)
+	g.returned.Store(true)
+}
@@ -0,0 +1,87 @@
+package iterstest
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// Counter records how a counting source was consumed. It is safe to read
+// while the source is being iterated from other goroutines.
+type Counter struct {
+	pulled     atomic.Int64
+	iterations atomic.Int64
+	stops      atomic.Int64
+}
+
+// Pulled returns the number of elements yielded by the source across all
+// iterations.
+func (c *Counter) Pulled() int {
+	return int(c.pulled.Load())
+}
+
+// Iterations returns the number of times the source was ranged over.
+func (c *Counter) Iterations() int {
+	return int(c.iterations.Load())
+}
+
+// Stops returns the number of iterations in which the consumer stopped the
+// source before it was exhausted.
+func (c *Counter) Stops() int {
+	return int(c.stops.Load())
+}
+
+// Reset sets all counts back to zero.
+func (c *Counter) Reset() {
+	c.pulled.Store(0)
+	c.iterations.Store(0)
+	c.stops.Store(0)
+}
+
+// Counting returns a sequence that yields the elements of seq and counts
+// them in the returned Counter. Wrapping the input of a combinator shows
+// how many elements it pulled, which verifies that it is lazy:
+//
+//	src, c := iterstest.Counting(iterstest.Naturals())
+//	iters.First(src)
+//	// c.Pulled() == 1
+func Counting[T any](seq iter.Seq[T]) (iter.Seq[T], *Counter) {
+	c := new(Counter)
+	return func(yield func(T) bool) {
+		c.iterations.Add(1)
+		for item := range seq {
+			c.pulled.Add(1)
+			if !yield(item) {
+				c.stops.Add(1)
+				return
+			}
+		}
+	}, c
+}
+
+// Counting2 is the keyed companion to Counting.
+func Counting2[K, V any](seq2 iter.Seq2[K, V]) (iter.Seq2[K, V], *Counter) {
+	c := new(Counter)
+	return func(yield func(K, V) bool) {
+		c.iterations.Add(1)
+		for k, v := range seq2 {
+			c.pulled.Add(1)
+			if !yield(k, v) {
+				c.stops.Add(1)
+				return
+			}
+		}
+	}, c
+}
+
+// Naturals returns an infinite sequence of the integers 0, 1, 2, and so on.
+// Combined with Counting it makes a convenient source for laziness tests,
+// since a combinator that is not lazy never returns.
+func Naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
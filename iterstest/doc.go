@@ -0,0 +1,5 @@
+// Package iterstest provides helpers for testing iter.Seq and iter.Seq2
+// producers and combinators: contract checks for yield, counting sources
+// for verifying laziness, and goroutine leak detection for concurrent
+// operators.
+package iterstest
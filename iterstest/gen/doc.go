@@ -0,0 +1,11 @@
+// Package gen provides random value generators for property-based and fuzz
+// testing of sequence producers and combinators.
+//
+// A Gen draws a value from a *rand.Rand. Generators compose: Slice, Seq and
+// Seq2 build random sequences from generators of their elements, and Map
+// derives new generators from existing ones. ForAll checks a property
+// against many generated values in an ordinary test, and Fuzz turns a
+// property into a testing.F fuzz target whose input bytes drive the
+// generator, so that failing inputs found by the fuzzer are saved and
+// replayed like any other corpus entry.
+package gen
@@ -0,0 +1,109 @@
+package gen
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+)
+
+// Gen generates random values of type T.
+type Gen[T any] func(r *rand.Rand) T
+
+// Const returns a generator that always produces v.
+func Const[T any](v T) Gen[T] {
+	return func(*rand.Rand) T { return v }
+}
+
+// OneOf returns a generator that picks uniformly from values. It panics if
+// values is empty.
+func OneOf[T any](values ...T) Gen[T] {
+	if len(values) == 0 {
+		panic("gen: OneOf requires at least one value")
+	}
+	return func(r *rand.Rand) T { return values[r.IntN(len(values))] }
+}
+
+// Bool returns a generator of booleans.
+func Bool() Gen[bool] {
+	return func(r *rand.Rand) bool { return r.Uint64()&1 == 1 }
+}
+
+// Int returns a generator of integers in the closed interval [lo, hi]. It
+// panics if lo > hi.
+func Int(lo, hi int) Gen[int] {
+	if lo > hi {
+		panic("gen: Int requires lo <= hi")
+	}
+	span := uint64(hi - lo)
+	return func(r *rand.Rand) int {
+		if span == ^uint64(0) {
+			return lo + int(r.Uint64())
+		}
+		return lo + int(r.Uint64N(span+1))
+	}
+}
+
+// Float64 returns a generator of floats in the half-open interval [lo, hi).
+func Float64(lo, hi float64) Gen[float64] {
+	return func(r *rand.Rand) float64 { return lo + r.Float64()*(hi-lo) }
+}
+
+// String returns a generator of strings of at most maxLen runes drawn from
+// alphabet. When alphabet is empty, lowercase ASCII letters are used.
+func String(alphabet string, maxLen int) Gen[string] {
+	if alphabet == "" {
+		alphabet = "abcdefghijklmnopqrstuvwxyz"
+	}
+	runes := Slice(OneOf([]rune(alphabet)...), maxLen)
+	return func(r *rand.Rand) string { return string(runes(r)) }
+}
+
+// Map returns a generator that applies fn to the values produced by g.
+func Map[T, R any](g Gen[T], fn func(T) R) Gen[R] {
+	return func(r *rand.Rand) R { return fn(g(r)) }
+}
+
+// Slice returns a generator of slices of at most maxLen elements drawn from
+// elem. Short slices, including empty ones, are as likely as long ones.
+func Slice[T any](elem Gen[T], maxLen int) Gen[[]T] {
+	return func(r *rand.Rand) []T {
+		if maxLen <= 0 {
+			return nil
+		}
+		s := make([]T, r.IntN(maxLen+1))
+		for i := range s {
+			s[i] = elem(r)
+		}
+		return s
+	}
+}
+
+// Seq returns a generator of finite sequences of at most maxLen elements
+// drawn from elem. The generated sequences can be iterated any number of
+// times. Use Slice instead when the generated value should be printed as
+// part of a failure.
+func Seq[T any](elem Gen[T], maxLen int) Gen[iter.Seq[T]] {
+	return Map(Slice(elem, maxLen), slices.Values[[]T])
+}
+
+// Seq2 returns a generator of finite keyed sequences of at most maxLen
+// pairs, with keys drawn from key and values drawn from value.
+func Seq2[K, V any](key Gen[K], value Gen[V], maxLen int) Gen[iter.Seq2[K, V]] {
+	return func(r *rand.Rand) iter.Seq2[K, V] {
+		n := 0
+		if maxLen > 0 {
+			n = r.IntN(maxLen + 1)
+		}
+		keys, values := make([]K, n), make([]V, n)
+		for i := range n {
+			keys[i], values[i] = key(r), value(r)
+		}
+		return func(yield func(K, V) bool) {
+			for i := range keys {
+				if !yield(keys[i], values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
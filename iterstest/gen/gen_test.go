@@ -0,0 +1,100 @@
+package gen_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleSlice() {
+	words := gen.Slice(gen.OneOf("a", "b", "c"), 5)
+
+	// The same input bytes always generate the same value.
+	r1 := gen.NewRand([]byte("seed bytes"))
+	r2 := gen.NewRand([]byte("seed bytes"))
+	fmt.Println(slices.Equal(words(r1), words(r2)))
+	// Output:
+	// true
+}
+
+func TestGenerators(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for range 1000 {
+		if n := gen.Int(-3, 3)(r); n < -3 || n > 3 {
+			t.Fatalf("Int: %d out of range", n)
+		}
+		if f := gen.Float64(1, 2)(r); f < 1 || f >= 2 {
+			t.Fatalf("Float64: %v out of range", f)
+		}
+		if s := gen.String("xy", 5)(r); len(s) > 5 || strings.Trim(s, "xy") != "" {
+			t.Fatalf("String: unexpected %q", s)
+		}
+		if s := gen.Slice(gen.Const(1), 4)(r); len(s) > 4 {
+			t.Fatalf("Slice: too long %v", s)
+		}
+		if v := gen.OneOf("a", "b")(r); v != "a" && v != "b" {
+			t.Fatalf("OneOf: unexpected %q", v)
+		}
+	}
+
+	seq := gen.Seq(gen.Int(0, 9), 10)(r)
+	if a, b := slices.Collect(seq), slices.Collect(seq); !slices.Equal(a, b) {
+		t.Fatalf("Seq: expected repeatable iteration, got %v and %v", a, b)
+	}
+
+	seq2 := gen.Seq2(gen.Int(0, 9), gen.Bool(), 10)(r)
+	count := 0
+	for range seq2 {
+		count++
+	}
+	for range seq2 {
+		count--
+	}
+	if count != 0 {
+		t.Fatalf("Seq2: expected repeatable iteration")
+	}
+}
+
+func TestNewRandIsDeterministic(t *testing.T) {
+	g := gen.Slice(gen.Int(0, 1000), 50)
+	for _, data := range [][]byte{nil, {0}, {1, 2, 3}, []byte(strings.Repeat("fuzz", 40))} {
+		if a, b := g(gen.NewRand(data)), g(gen.NewRand(data)); !slices.Equal(a, b) {
+			t.Fatalf("expected the same value for %v, got %v and %v", data, a, b)
+		}
+	}
+}
+
+func TestForAllReportsCounterexample(t *testing.T) {
+	defer func(seed uint64) { gen.Seed = seed }(gen.Seed)
+	gen.Seed = 42
+
+	f := &failTB{TB: t}
+	gen.ForAll(f, gen.Int(0, 100), func(n int) bool { return n < 50 })
+	if !strings.Contains(f.msg, "seed 42") {
+		t.Fatalf("expected the seed in the failure, got %q", f.msg)
+	}
+}
+
+type failTB struct {
+	testing.TB
+	msg string
+}
+
+func (f *failTB) Helper() {}
+
+func (f *failTB) Errorf(format string, args ...any) {
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func FuzzSliceLength(f *testing.F) {
+	gen.Fuzz(f, gen.Slice(gen.Bool(), 8), func(t *testing.T, s []bool) {
+		if len(s) > 8 {
+			t.Fatalf("expected at most 8 elements, got %d", len(s))
+		}
+	})
+}
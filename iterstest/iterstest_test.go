@@ -0,0 +1,149 @@
+package iterstest_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/picatz/iters/iterstest"
+)
+
+// fakeTB records the errors reported by the helpers under test. Errors may
+// be reported from other goroutines.
+type fakeTB struct {
+	testing.TB
+	mu       sync.Mutex
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeTB) runCleanups() {
+	for _, fn := range slices.Backward(f.cleanups) {
+		fn()
+	}
+}
+
+func ExampleCounting() {
+	src, c := iterstest.Counting(iterstest.Naturals())
+
+	for n := range src {
+		if n == 2 {
+			break
+		}
+	}
+
+	fmt.Println(c.Pulled(), c.Iterations(), c.Stops())
+	// Output:
+	// 3 1 1
+}
+
+func TestCheckSeqAcceptsWellBehavedSequences(t *testing.T) {
+	iterstest.CheckSeq(t, slices.Values([]int{1, 2, 3}))
+	iterstest.CheckSeq(t, slices.Values([]int(nil)))
+	iterstest.CheckSeq(t, iterstest.Naturals())
+	iterstest.CheckSeq2(t, slices.All([]string{"a", "b"}))
+}
+
+func TestCheckSeqDetectsYieldAfterFalse(t *testing.T) {
+	ignoresStop := func(yield func(int) bool) {
+		for i := range 3 {
+			yield(i)
+		}
+	}
+
+	f := &fakeTB{TB: t}
+	iterstest.CheckSeq(f, ignoresStop)
+	if len(f.errors) == 0 || !strings.Contains(f.errors[0], "after it returned false") {
+		t.Fatalf("expected a yield-after-false error, got %q", f.errors)
+	}
+
+	ignoresStop2 := func(yield func(int, int) bool) {
+		yield(0, 0)
+		yield(1, 1)
+	}
+
+	f = &fakeTB{TB: t}
+	iterstest.CheckSeq2(f, ignoresStop2)
+	if len(f.errors) == 0 || !strings.Contains(f.errors[0], "after it returned false") {
+		t.Fatalf("expected a yield-after-false error, got %q", f.errors)
+	}
+}
+
+func TestCheckSeqDetectsYieldAfterReturn(t *testing.T) {
+	var wg sync.WaitGroup
+	escapes := func(yield func(int) bool) {
+		wg.Go(func() {
+			time.Sleep(time.Millisecond)
+			yield(0)
+		})
+	}
+
+	f := &fakeTB{TB: t}
+	iterstest.CheckSeq(f, escapes)
+	wg.Wait()
+
+	if len(f.errors) == 0 || !strings.Contains(f.errors[0], "after the sequence function returned") {
+		t.Fatalf("expected a yield-after-return error, got %q", f.errors)
+	}
+}
+
+func TestCounting2(t *testing.T) {
+	src, c := iterstest.Counting2(slices.All([]string{"a", "b", "c"}))
+
+	for range src {
+	}
+	for range src {
+		break
+	}
+
+	if c.Pulled() != 4 || c.Iterations() != 2 || c.Stops() != 1 {
+		t.Fatalf("unexpected counts pulled=%d iterations=%d stops=%d", c.Pulled(), c.Iterations(), c.Stops())
+	}
+
+	c.Reset()
+	if c.Pulled() != 0 || c.Iterations() != 0 || c.Stops() != 0 {
+		t.Fatalf("expected counts to be reset")
+	}
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	defer func(timeout time.Duration) { iterstest.LeakTimeout = timeout }(iterstest.LeakTimeout)
+	iterstest.LeakTimeout = 50 * time.Millisecond
+
+	f := &fakeTB{TB: t}
+	iterstest.VerifyNoLeaks(f)
+	done := make(chan struct{})
+	go func() { <-done }()
+	f.runCleanups()
+	close(done)
+
+	if len(f.errors) != 1 || !strings.Contains(f.errors[0], "1 goroutine(s) leaked") {
+		t.Fatalf("expected a leak to be reported, got %q", f.errors)
+	}
+
+	f = &fakeTB{TB: t}
+	iterstest.VerifyNoLeaks(f)
+	finished := make(chan struct{})
+	go func() { time.Sleep(10 * time.Millisecond); close(finished) }()
+	f.runCleanups()
+	<-finished
+
+	if len(f.errors) != 0 {
+		t.Fatalf("expected no leak once the goroutine exits, got %q", f.errors)
+	}
+}
@@ -0,0 +1,73 @@
+package iterstest
+
+import (
+	"bytes"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// LeakTimeout is how long VerifyNoLeaks waits for goroutines started during
+// a test to exit before reporting them.
+var LeakTimeout = time.Second
+
+// VerifyNoLeaks records the goroutines running when it is called and, when
+// the test finishes, reports any goroutine started since then that is still
+// running after LeakTimeout. Call it at the start of tests of operators
+// that start goroutines, such as iters.Split. Tests using it must not run
+// in parallel with other tests, since their goroutines would be reported
+// too.
+func VerifyNoLeaks(t testing.TB) {
+	t.Helper()
+
+	before := goroutines()
+	t.Cleanup(func() {
+		t.Helper()
+
+		var leaked []string
+		deadline := time.Now().Add(LeakTimeout)
+		for {
+			leaked = leaked[:0]
+			for id, stack := range goroutines() {
+				if _, ok := before[id]; !ok {
+					leaked = append(leaked, stack)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if len(leaked) > 0 {
+			slices.Sort(leaked)
+			t.Errorf("%d goroutine(s) leaked:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+	})
+}
+
+// goroutines returns the stacks of the running goroutines, keyed by their
+// "goroutine N" header, excluding the calling goroutine.
+func goroutines() map[string]string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[string]string)
+	for i, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if i == 0 {
+			continue // the calling goroutine
+		}
+		header, _, _ := bytes.Cut(stack, []byte(" ["))
+		stacks[string(header)] = string(stack)
+	}
+	return stacks
+}
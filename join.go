@@ -0,0 +1,295 @@
+package iters
+
+import (
+	"cmp"
+	"iter"
+)
+
+// JoinRow is a row produced by a join. For inner joins both sides are
+// always present; for outer joins HasLeft or HasRight is false, and the
+// corresponding value is zero, when a key has no match on that side.
+type JoinRow[V1, V2 any] struct {
+	Left     V1
+	Right    V2
+	HasLeft  bool
+	HasRight bool
+}
+
+// JoinKind selects which unmatched rows MergeJoin keeps.
+type JoinKind int
+
+const (
+	// JoinInner keeps only keys present on both sides.
+	JoinInner JoinKind = iota
+	// JoinLeft also keeps left rows without a match.
+	JoinLeft
+	// JoinRight also keeps right rows without a match.
+	JoinRight
+	// JoinFull keeps unmatched rows from both sides.
+	JoinFull
+)
+
+func (k JoinKind) keepsLeft() bool  { return k == JoinLeft || k == JoinFull }
+func (k JoinKind) keepsRight() bool { return k == JoinRight || k == JoinFull }
+
+// The hash joins read left and right in step until one of them ends, and
+// build a hash table from that smaller input. The larger input is then
+// streamed past the table, so memory is bounded by twice the size of the
+// smaller input. Rows are yielded in the order of the streamed input, each
+// key's matches in the order of the other input, and keys with several
+// rows on both sides produce every combination. Rows of the hashed input
+// that an outer join keeps unmatched come last, in input order. Which
+// input is hashed depends on their lengths; when they are equally long the
+// right input is hashed.
+
+// HashJoin returns the inner join of left and right: a row for every pair
+// of left and right elements with equal keys.
+func HashJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, JoinRow[V1, V2]] {
+	return hashJoin(left, right, JoinInner)
+}
+
+// LeftJoin returns the left outer join of left and right: the rows of
+// HashJoin plus a row, with HasRight false, for each left element whose
+// key is not in right.
+func LeftJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, JoinRow[V1, V2]] {
+	return hashJoin(left, right, JoinLeft)
+}
+
+// FullOuterJoin returns the full outer join of left and right: the rows of
+// HashJoin plus a row for each element of either side whose key is not on
+// the other side.
+func FullOuterJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, JoinRow[V1, V2]] {
+	return hashJoin(left, right, JoinFull)
+}
+
+// SemiJoin returns the elements of left whose key appears in right, each
+// once, in the order of left.
+func SemiJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, V1] {
+	return filterJoin(left, right, true)
+}
+
+// AntiJoin returns the elements of left whose key does not appear in
+// right, in the order of left.
+func AntiJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, V1] {
+	return filterJoin(left, right, false)
+}
+
+// joinInputs holds the elements read from each side of a join while
+// looking for the smaller input, and whether that is left.
+type joinInputs[K, V1, V2 any] struct {
+	left     []Pair[K, V1]
+	right    []Pair[K, V2]
+	leftDone bool
+}
+
+// readJoinInputs pulls left and right in step until one of them ends.
+func readJoinInputs[K, V1, V2 any](nextLeft func() (K, V1, bool), nextRight func() (K, V2, bool)) *joinInputs[K, V1, V2] {
+	in := new(joinInputs[K, V1, V2])
+	for {
+		k1, v1, ok1 := nextLeft()
+		if ok1 {
+			in.left = append(in.left, Pair[K, V1]{k1, v1})
+		}
+		k2, v2, ok2 := nextRight()
+		if ok2 {
+			in.right = append(in.right, Pair[K, V2]{k2, v2})
+		}
+		if !ok2 {
+			// Right ended first or both ended together; hash right.
+			return in
+		}
+		if !ok1 {
+			in.leftDone = true
+			return in
+		}
+	}
+}
+
+// rest returns a sequence of the buffered entries followed by the rest of
+// the input read through next.
+func rest[K, V any](buffered []Pair[K, V], next func() (K, V, bool)) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range buffered {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+		for {
+			k, v, ok := next()
+			if !ok || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+func hashJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], kind JoinKind) iter.Seq2[K, JoinRow[V1, V2]] {
+	return func(yield func(K, JoinRow[V1, V2]) bool) {
+		nextLeft, stopLeft := iter.Pull2(left)
+		defer stopLeft()
+		nextRight, stopRight := iter.Pull2(right)
+		defer stopRight()
+
+		in := readJoinInputs(nextLeft, nextRight)
+		if in.leftDone {
+			probeJoin(in.left, rest(in.right, nextRight), kind.keepsLeft(), kind.keepsRight(),
+				func(k K, l V1, hasL bool, r V2, hasR bool) bool {
+					return yield(k, JoinRow[V1, V2]{Left: l, Right: r, HasLeft: hasL, HasRight: hasR})
+				})
+			return
+		}
+		probeJoin(in.right, rest(in.left, nextLeft), kind.keepsRight(), kind.keepsLeft(),
+			func(k K, r V2, hasR bool, l V1, hasL bool) bool {
+				return yield(k, JoinRow[V1, V2]{Left: l, Right: r, HasLeft: hasL, HasRight: hasR})
+			})
+	}
+}
+
+// probeJoin joins the hashed entries of build with the streamed probe
+// input, passing each row to emit until it returns false.
+func probeJoin[K comparable, B, P any](build []Pair[K, B], probe iter.Seq2[K, P], keepBuild, keepProbe bool, emit func(k K, b B, hasB bool, p P, hasP bool) bool) {
+	index := make(map[K][]int, len(build))
+	for i, e := range build {
+		index[e.Key] = append(index[e.Key], i)
+	}
+	matched := make([]bool, len(build))
+
+	var (
+		zeroB B
+		zeroP P
+	)
+	for k, p := range probe {
+		matches := index[k]
+		if len(matches) == 0 && keepProbe && !emit(k, zeroB, false, p, true) {
+			return
+		}
+		for _, i := range matches {
+			matched[i] = true
+			if !emit(k, build[i].Value, true, p, true) {
+				return
+			}
+		}
+	}
+	if !keepBuild {
+		return
+	}
+	for i, e := range build {
+		if !matched[i] && !emit(e.Key, e.Value, true, zeroP, false) {
+			return
+		}
+	}
+}
+
+func filterJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], keepMatched bool) iter.Seq2[K, V1] {
+	return func(yield func(K, V1) bool) {
+		nextLeft, stopLeft := iter.Pull2(left)
+		defer stopLeft()
+		nextRight, stopRight := iter.Pull2(right)
+		defer stopRight()
+
+		in := readJoinInputs(nextLeft, nextRight)
+		if !in.leftDone {
+			keys := make(map[K]struct{}, len(in.right))
+			for k := range rest(in.right, nextRight) {
+				keys[k] = struct{}{}
+			}
+			for k, v := range rest(in.left, nextLeft) {
+				if _, ok := keys[k]; ok == keepMatched && !yield(k, v) {
+					return
+				}
+			}
+			return
+		}
+
+		// Left is the smaller input: find which of its keys occur in
+		// right, then yield its elements in order.
+		index := make(map[K]bool, len(in.left))
+		for _, e := range in.left {
+			index[e.Key] = false
+		}
+		unmatched := len(index)
+		for k := range rest(in.right, nextRight) {
+			if found, ok := index[k]; ok && !found {
+				index[k] = true
+				if unmatched--; unmatched == 0 {
+					break // the rest of right cannot change the result
+				}
+			}
+		}
+		for _, e := range in.left {
+			if index[e.Key] == keepMatched && !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// MergeJoin joins left and right, which must both be sorted by key in
+// ascending order, keeping unmatched rows as selected by kind. It reads
+// each input once and yields rows in key order. Only the right rows that
+// share the current key are buffered, so memory is constant for unique
+// keys. Inputs that are not sorted produce unspecified results.
+func MergeJoin[K cmp.Ordered, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], kind JoinKind) iter.Seq2[K, JoinRow[V1, V2]] {
+	return MergeJoinFunc(left, right, kind, cmp.Compare[K])
+}
+
+// MergeJoinFunc is like MergeJoin for inputs sorted by cmp.
+func MergeJoinFunc[K, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], kind JoinKind, cmp func(a, b K) int) iter.Seq2[K, JoinRow[V1, V2]] {
+	return func(yield func(K, JoinRow[V1, V2]) bool) {
+		nextLeft, stopLeft := iter.Pull2(left)
+		defer stopLeft()
+		nextRight, stopRight := iter.Pull2(right)
+		defer stopRight()
+
+		var (
+			run   []V2
+			zero1 V1
+			zero2 V2
+		)
+		k1, v1, ok1 := nextLeft()
+		k2, v2, ok2 := nextRight()
+		for ok1 || ok2 {
+			if !ok2 && !kind.keepsLeft() || !ok1 && !kind.keepsRight() {
+				return
+			}
+
+			var c int
+			switch {
+			case !ok2:
+				c = -1
+			case !ok1:
+				c = 1
+			default:
+				c = cmp(k1, k2)
+			}
+
+			switch {
+			case c < 0:
+				if kind.keepsLeft() && !yield(k1, JoinRow[V1, V2]{Left: v1, HasLeft: true, Right: zero2}) {
+					return
+				}
+				k1, v1, ok1 = nextLeft()
+			case c > 0:
+				if kind.keepsRight() && !yield(k2, JoinRow[V1, V2]{Left: zero1, Right: v2, HasRight: true}) {
+					return
+				}
+				k2, v2, ok2 = nextRight()
+			default:
+				key := k2
+				run = run[:0]
+				for ok2 && cmp(k2, key) == 0 {
+					run = append(run, v2)
+					k2, v2, ok2 = nextRight()
+				}
+				for ok1 && cmp(k1, key) == 0 {
+					for _, r := range run {
+						if !yield(k1, JoinRow[V1, V2]{Left: v1, Right: r, HasLeft: true, HasRight: true}) {
+							return
+						}
+					}
+					k1, v1, ok1 = nextLeft()
+				}
+			}
+		}
+	}
+}
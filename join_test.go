@@ -0,0 +1,188 @@
+package iters_test
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleLeftJoin() {
+	users := slices.All([]string{"ada", "bob", "cy"})
+	orders := iters.Zip(slices.Values([]int{0, 2, 0}), slices.Values([]string{"book", "lamp", "pen"}))
+
+	for id, row := range iters.LeftJoin(users, orders) {
+		fmt.Println(id, row.Left, row.Right, row.HasRight)
+	}
+	// Output:
+	// 0 ada book true
+	// 0 ada pen true
+	// 1 bob  false
+	// 2 cy lamp true
+}
+
+func ExampleMergeJoin() {
+	left := iters.Zip(slices.Values([]int{1, 2, 4}), slices.Values([]string{"a", "b", "d"}))
+	right := iters.Zip(slices.Values([]int{2, 3, 4}), slices.Values([]float64{0.2, 0.3, 0.4}))
+
+	for k, row := range iters.MergeJoin(left, right, iters.JoinFull) {
+		fmt.Println(k, row)
+	}
+	// Output:
+	// 1 {a 0 true false}
+	// 2 {b 0.2 true true}
+	// 3 { 0.3 false true}
+	// 4 {d 0.4 true true}
+}
+
+type joinInput struct {
+	left  []joinKV
+	right []joinKV
+}
+
+type joinKV struct {
+	k, v int
+}
+
+func joinSeq(kvs []joinKV) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for _, kv := range kvs {
+			if !yield(kv.k, kv.v) {
+				return
+			}
+		}
+	}
+}
+
+var joinInputs = func(r *rand.Rand) joinInput {
+	kv := func(r *rand.Rand) joinKV { return joinKV{gen.Int(0, 6)(r), gen.Int(0, 100)(r)} }
+	return joinInput{gen.Slice(kv, 10)(r), gen.Slice(kv, 10)(r)}
+}
+
+// referenceJoin computes a join with nested loops, returning its rows as
+// sorted strings so that results can be compared regardless of order.
+func referenceJoin(in joinInput, kind iters.JoinKind) []string {
+	var rows []string
+	matchedRight := make([]bool, len(in.right))
+	for _, l := range in.left {
+		matched := false
+		for j, r := range in.right {
+			if l.k == r.k {
+				matched, matchedRight[j] = true, true
+				rows = append(rows, fmt.Sprint(l.k, iters.JoinRow[int, int]{Left: l.v, Right: r.v, HasLeft: true, HasRight: true}))
+			}
+		}
+		if !matched && (kind == iters.JoinLeft || kind == iters.JoinFull) {
+			rows = append(rows, fmt.Sprint(l.k, iters.JoinRow[int, int]{Left: l.v, HasLeft: true}))
+		}
+	}
+	for j, r := range in.right {
+		if !matchedRight[j] && (kind == iters.JoinRight || kind == iters.JoinFull) {
+			rows = append(rows, fmt.Sprint(r.k, iters.JoinRow[int, int]{Right: r.v, HasRight: true}))
+		}
+	}
+	slices.Sort(rows)
+	return rows
+}
+
+func joinRows(seq iter.Seq2[int, iters.JoinRow[int, int]]) []string {
+	var rows []string
+	for k, row := range seq {
+		rows = append(rows, fmt.Sprint(k, row))
+	}
+	slices.Sort(rows)
+	return rows
+}
+
+func TestHashJoinsMatchNestedLoops(t *testing.T) {
+	joins := []struct {
+		name string
+		join func(l, r iter.Seq2[int, int]) iter.Seq2[int, iters.JoinRow[int, int]]
+		kind iters.JoinKind
+	}{
+		{"HashJoin", iters.HashJoin[int, int, int], iters.JoinInner},
+		{"LeftJoin", iters.LeftJoin[int, int, int], iters.JoinLeft},
+		{"FullOuterJoin", iters.FullOuterJoin[int, int, int], iters.JoinFull},
+	}
+	for _, j := range joins {
+		t.Run(j.name, func(t *testing.T) {
+			gen.ForAll(t, joinInputs, func(in joinInput) bool {
+				got := joinRows(j.join(joinSeq(in.left), joinSeq(in.right)))
+				return slices.Equal(got, referenceJoin(in, j.kind))
+			})
+		})
+	}
+}
+
+func TestMergeJoinMatchesNestedLoops(t *testing.T) {
+	byKey := func(a, b joinKV) int { return a.k - b.k }
+	for _, kind := range []iters.JoinKind{iters.JoinInner, iters.JoinLeft, iters.JoinRight, iters.JoinFull} {
+		t.Run(fmt.Sprint(kind), func(t *testing.T) {
+			gen.ForAll(t, joinInputs, func(in joinInput) bool {
+				slices.SortStableFunc(in.left, byKey)
+				slices.SortStableFunc(in.right, byKey)
+
+				seq := iters.MergeJoin(joinSeq(in.left), joinSeq(in.right), kind)
+				var keys []int
+				for k := range seq {
+					keys = append(keys, k)
+				}
+				return slices.IsSorted(keys) && slices.Equal(joinRows(seq), referenceJoin(in, kind))
+			})
+		})
+	}
+}
+
+func TestSemiAndAntiJoin(t *testing.T) {
+	gen.ForAll(t, joinInputs, func(in joinInput) bool {
+		var semi, anti []joinKV
+		for _, l := range in.left {
+			if slices.ContainsFunc(in.right, func(r joinKV) bool { return r.k == l.k }) {
+				semi = append(semi, l)
+			} else {
+				anti = append(anti, l)
+			}
+		}
+
+		collect := func(seq iter.Seq2[int, int]) []joinKV {
+			var out []joinKV
+			for k, v := range seq {
+				out = append(out, joinKV{k, v})
+			}
+			return out
+		}
+		return slices.Equal(collect(iters.SemiJoin(joinSeq(in.left), joinSeq(in.right))), semi) &&
+			slices.Equal(collect(iters.AntiJoin(joinSeq(in.left), joinSeq(in.right))), anti)
+	})
+}
+
+func TestHashJoinBuildsFromSmallerInput(t *testing.T) {
+	naturals := iters.Zip(iterstest.Naturals(), iterstest.Naturals())
+	small := slices.All([]string{"zero", "one", "two"})
+
+	// The left input is infinite, so the join only finishes because the
+	// small right input is hashed and the left one streamed.
+	var got []string
+	for _, row := range iters.Limit2(iters.HashJoin(naturals, small), 3) {
+		got = append(got, row.Right)
+	}
+	if !slices.Equal(got, []string{"zero", "one", "two"}) {
+		t.Fatalf("HashJoin: expected [zero one two], got %v", got)
+	}
+
+	var keys []int
+	for k := range iters.Limit2(iters.SemiJoin(small, naturals), 3) {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []int{0, 1, 2}) {
+		t.Fatalf("SemiJoin: expected [0 1 2], got %v", keys)
+	}
+
+	iterstest.CheckSeq2(t, iters.FullOuterJoin(small, slices.All([]int{1, 2, 3, 4, 5})))
+	iterstest.CheckSeq2(t, iters.MergeJoin(small, slices.All([]int{1, 2, 3, 4, 5}), iters.JoinFull))
+}
@@ -0,0 +1,50 @@
+package iters
+
+import "iter"
+
+// Keys returns a sequence of the keys of seq2, in order.
+func Keys[K, V any](seq2 iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq2 {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the values of seq2, in order.
+func Values[K, V any](seq2 iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq2 {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Swap returns a sequence of the pairs of seq2 with each key and value
+// exchanged.
+func Swap[K, V any](seq2 iter.Seq2[K, V]) iter.Seq2[V, K] {
+	return func(yield func(V, K) bool) {
+		for k, v := range seq2 {
+			if !yield(v, k) {
+				return
+			}
+		}
+	}
+}
+
+// WithKey returns a sequence that pairs each element of seq with the key
+// computed from it by keyFn, for use with keyed combinators such as
+// [HashJoin] or [Chunk2].
+func WithKey[K, V any](seq iter.Seq[V], keyFn func(V) K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for item := range seq {
+			if !yield(keyFn(item), item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package iters_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func ExampleKeys() {
+	pairs := slices.All([]string{"a", "b"})
+	fmt.Println(slices.Collect(iters.Keys(pairs)), slices.Collect(iters.Values(pairs)))
+	// Output:
+	// [0 1] [a b]
+}
+
+func ExampleWithKey() {
+	words := slices.Values([]string{"go", "zig", "rust"})
+	byLength := maps.Collect(iters.WithKey(words, func(s string) int { return len(s) }))
+	fmt.Println(byLength)
+	// Output:
+	// map[2:go 3:zig 4:rust]
+}
+
+func TestKeysValuesSwap(t *testing.T) {
+	pairs := slices.All([]string{"a", "b", "c"})
+
+	if got := slices.Collect(iters.Keys(pairs)); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Fatalf("Keys: expected [0 1 2], got %v", got)
+	}
+	if got := slices.Collect(iters.Values(pairs)); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Fatalf("Values: expected [a b c], got %v", got)
+	}
+	if got := maps.Collect(iters.Swap(pairs)); !maps.Equal(got, map[string]int{"a": 0, "b": 1, "c": 2}) {
+		t.Fatalf("Swap: unexpected %v", got)
+	}
+
+	iterstest.CheckSeq(t, iters.Keys(pairs))
+	iterstest.CheckSeq(t, iters.Values(pairs))
+	iterstest.CheckSeq2(t, iters.Swap(pairs))
+	iterstest.CheckSeq2(t, iters.WithKey(slices.Values([]string{"a", "b"}), strings.ToUpper))
+}
+
+func TestWithKeyRoundTrip(t *testing.T) {
+	words := []string{"apple", "Banana", "cherry"}
+	keyed := iters.WithKey(slices.Values(words), strings.ToLower)
+	if got := slices.Collect(iters.Values(keyed)); !slices.Equal(got, words) {
+		t.Fatalf("WithKey: expected values %v, got %v", words, got)
+	}
+	if got := slices.Collect(iters.Keys(keyed)); !slices.Equal(got, []string{"apple", "banana", "cherry"}) {
+		t.Fatalf("WithKey: unexpected keys %v", got)
+	}
+}
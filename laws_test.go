@@ -0,0 +1,106 @@
+package iters_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+var smallInts = gen.Slice(gen.Int(-20, 20), 32)
+
+type threeSlices struct {
+	a, b, c []int
+}
+
+func FuzzConcatAssociative(f *testing.F) {
+	g := func(r *rand.Rand) threeSlices {
+		return threeSlices{smallInts(r), smallInts(r), smallInts(r)}
+	}
+	gen.Fuzz(f, g, func(t *testing.T, v threeSlices) {
+		a, b, c := slices.Values(v.a), slices.Values(v.b), slices.Values(v.c)
+
+		left := slices.Collect(iters.Concat(iters.Concat(a, b), c))
+		right := slices.Collect(iters.Concat(a, iters.Concat(b, c)))
+		if !slices.Equal(left, right) {
+			t.Fatalf("Concat: (a+b)+c = %v, a+(b+c) = %v", left, right)
+		}
+	})
+}
+
+func FuzzMapComposition(f *testing.F) {
+	double := func(n int) int { return n * 2 }
+	offset := func(n int) int { return n - 7 }
+
+	gen.Fuzz(f, smallInts, func(t *testing.T, s []int) {
+		composed := slices.Collect(iters.Map(iters.Map(slices.Values(s), double), offset))
+		direct := slices.Collect(iters.Map(slices.Values(s), func(n int) int { return offset(double(n)) }))
+		if !slices.Equal(composed, direct) {
+			t.Fatalf("Map: composed %v, direct %v", composed, direct)
+		}
+	})
+}
+
+func FuzzFilterIdempotent(f *testing.F) {
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	gen.Fuzz(f, smallInts, func(t *testing.T, s []int) {
+		once := slices.Collect(iters.Filter(slices.Values(s), isEven))
+		twice := slices.Collect(iters.Filter(iters.Filter(slices.Values(s), isEven), isEven))
+		if !slices.Equal(once, twice) {
+			t.Fatalf("Filter: once %v, twice %v", once, twice)
+		}
+	})
+}
+
+func FuzzSortPermutation(f *testing.F) {
+	gen.Fuzz(f, smallInts, func(t *testing.T, s []int) {
+		sorted := slices.Collect(iters.Sort(slices.Values(s)))
+		if !slices.IsSorted(sorted) {
+			t.Fatalf("Sort: %v is not ordered", sorted)
+		}
+
+		want := slices.Clone(s)
+		slices.Sort(want)
+		if !slices.Equal(sorted, want) {
+			t.Fatalf("Sort: %v is not a permutation of %v", sorted, s)
+		}
+	})
+}
+
+func FuzzUniqueIdempotent(f *testing.F) {
+	gen.Fuzz(f, smallInts, func(t *testing.T, s []int) {
+		once := slices.Collect(iters.Unique(slices.Values(s)))
+		twice := slices.Collect(iters.Unique(iters.Unique(slices.Values(s))))
+		if !slices.Equal(once, twice) {
+			t.Fatalf("Unique: once %v, twice %v", once, twice)
+		}
+	})
+}
+
+type chunkInput struct {
+	items []int
+	size  int
+}
+
+func FuzzChunkRoundTrip(f *testing.F) {
+	sizes := gen.Int(1, 8)
+	g := func(r *rand.Rand) chunkInput {
+		return chunkInput{smallInts(r), sizes(r)}
+	}
+	gen.Fuzz(f, g, func(t *testing.T, v chunkInput) {
+		var flat []int
+		chunks := slices.Collect(iters.Chunk(slices.Values(v.items), v.size))
+		for i, chunk := range chunks {
+			if len(chunk) == 0 || len(chunk) > v.size || (i < len(chunks)-1 && len(chunk) != v.size) {
+				t.Fatalf("Chunk: chunk %d of size %d has length %d", i, v.size, len(chunk))
+			}
+			flat = append(flat, chunk...)
+		}
+		if !slices.Equal(flat, v.items) {
+			t.Fatalf("Chunk: flattened %v, want %v", flat, v.items)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package iters_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func TestCombinatorsSatisfySeqContract(t *testing.T) {
+	numbers := slices.Values([]int{5, 1, 4, 1, 3})
+	isOdd := func(n int) bool { return n%2 == 1 }
+
+	iterstest.CheckSeq(t, iters.Limit(iterstest.Naturals(), 10))
+	iterstest.CheckSeq(t, iters.Filter(numbers, isOdd))
+	iterstest.CheckSeq(t, iters.Map(numbers, func(n int) int { return n * 2 }))
+	iterstest.CheckSeq(t, iters.Unique(numbers))
+	iterstest.CheckSeq(t, iters.Concat(numbers, iterstest.Naturals()))
+	iterstest.CheckSeq(t, iters.After(iterstest.Naturals(), 3))
+	iterstest.CheckSeq2(t, iters.Zip(numbers, iterstest.Naturals()))
+}
+
+func TestCombinatorsAreLazy(t *testing.T) {
+	src, c := iterstest.Counting(iterstest.Naturals())
+
+	for range iters.Limit(src, 5) {
+	}
+	if c.Pulled() != 5 || c.Stops() != 1 {
+		t.Fatalf("Limit: expected 5 pulled and 1 stop, got %d and %d", c.Pulled(), c.Stops())
+	}
+
+	c.Reset()
+	if first, ok := iters.First(src); !ok || first != 0 {
+		t.Fatalf("First: expected 0, got %v, %v", first, ok)
+	}
+	if c.Pulled() != 1 {
+		t.Fatalf("First: expected 1 pulled, got %d", c.Pulled())
+	}
+
+	c.Reset()
+	if !iters.Contains(src, 7) {
+		t.Fatalf("Contains: expected 7 to be found")
+	}
+	if c.Pulled() != 8 {
+		t.Fatalf("Contains: expected 8 pulled, got %d", c.Pulled())
+	}
+}
+
+func TestSplitDoesNotLeak(t *testing.T) {
+	t.Run("consumed", func(t *testing.T) {
+		iterstest.VerifyNoLeaks(t)
+
+		keys, values := iters.Split(context.Background(), slices.All([]string{"a", "b", "c"}))
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			for range keys {
+			}
+		})
+		wg.Go(func() {
+			for range values {
+			}
+		})
+		wg.Wait()
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		iterstest.VerifyNoLeaks(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		keys, _ := iters.Split(ctx, iters.Zip(iterstest.Naturals(), iterstest.Naturals()))
+		// Only the keys are consumed, so the producer blocks on the first
+		// value until ctx is canceled.
+		for range keys {
+			break
+		}
+		cancel()
+	})
+}
@@ -5,35 +5,42 @@ import (
 )
 
 // Limit returns a sequence that yields at most n values from seq. When n is
-// zero or negative the returned sequence is empty.
+// zero or negative the returned sequence is empty. Iteration of seq stops as
+// soon as the nth value has been yielded, so no further values are pulled.
 func Limit[T any](seq iter.Seq[T], n int) iter.Seq[T] {
 	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
 		count := 0
 		for item := range seq {
-			if count >= n {
-				return
-			}
 			if !yield(item) {
 				return
 			}
 			count++
+			if count >= n {
+				return
+			}
 		}
 	}
 }
 
 // Limit2 returns a sequence that yields at most n key/value pairs from
-// seq2.
+// seq2. Like Limit, it stops pulling from seq2 after the nth pair.
 func Limit2[K, V any](seq2 iter.Seq2[K, V], n int) iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
 		count := 0
 		for k, v := range seq2 {
-			if count >= n {
-				return
-			}
 			if !yield(k, v) {
 				return
 			}
 			count++
+			if count >= n {
+				return
+			}
 		}
 	}
 }
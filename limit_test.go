@@ -161,3 +161,30 @@ func TestLimit2(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestLimitStopsPulling(t *testing.T) {
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	got := slices.Collect(iters.Limit(seq, 3))
+	if want := []int{0, 1, 2}; !slices.Equal(got, want) {
+		t.Fatalf("Limit: expected %v, got %v", want, got)
+	}
+	if pulled != 3 {
+		t.Fatalf("Limit: expected 3 values to be pulled, got %d", pulled)
+	}
+
+	pulled = 0
+	for range iters.Limit(seq, 0) {
+	}
+	if pulled != 0 {
+		t.Fatalf("Limit: expected no values to be pulled, got %d", pulled)
+	}
+}
@@ -6,7 +6,9 @@ import (
 )
 
 // Max returns the largest element produced by seq according to Go's
-// ordering for cmp.Ordered types. If seq is empty, ok is false.
+// ordering for cmp.Ordered types. As with slices.Max, if seq contains a
+// floating-point NaN the result is the first NaN. If seq is empty, ok is
+// false.
 func Max[T cmp.Ordered](seq iter.Seq[T]) (max T, ok bool) {
 	first := true
 	for item := range seq {
@@ -16,7 +18,7 @@ func Max[T cmp.Ordered](seq iter.Seq[T]) (max T, ok bool) {
 			ok = true
 			continue
 		}
-		if item > max {
+		if nanGreater(item, max) {
 			max = item
 		}
 	}
@@ -40,3 +42,26 @@ func MaxFunc[T any](seq iter.Seq[T], less func(a, b T) bool) (max T, ok bool) {
 	}
 	return
 }
+
+// Max2 returns the pair of seq2 with the largest key. When several pairs
+// share the largest key, the first is returned. A NaN key is treated as in
+// Max. If seq2 is empty, ok is false.
+func Max2[K cmp.Ordered, V any](seq2 iter.Seq2[K, V]) (key K, value V, ok bool) {
+	for k, v := range seq2 {
+		if !ok || nanGreater(k, key) {
+			key, value, ok = k, v, true
+		}
+	}
+	return
+}
+
+// MaxFunc2 returns the pair of seq2 that maximizes the provided less
+// function. If seq2 is empty, ok is false.
+func MaxFunc2[K, V any](seq2 iter.Seq2[K, V], less func(k1 K, v1 V, k2 K, v2 V) bool) (key K, value V, ok bool) {
+	for k, v := range seq2 {
+		if !ok || less(key, value, k, v) {
+			key, value, ok = k, v, true
+		}
+	}
+	return
+}
@@ -93,3 +93,18 @@ func TestMaxFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestMax2(t *testing.T) {
+	input := iters.Unpairs(slices.Values([]iters.Pair[int, string]{{1, "a"}, {3, "b"}, {3, "c"}, {2, "d"}}))
+
+	if k, v, ok := iters.Max2(input); !ok || k != 3 || v != "b" {
+		t.Fatalf("Max2: expected 3 b, got %v %v %v", k, v, ok)
+	}
+	byValue := func(_ int, a string, _ int, b string) bool { return a < b }
+	if k, v, ok := iters.MaxFunc2(input, byValue); !ok || k != 2 || v != "d" {
+		t.Fatalf("MaxFunc2: expected 2 d, got %v %v %v", k, v, ok)
+	}
+	if _, _, ok := iters.Max2(iters.Unpairs(slices.Values([]iters.Pair[int, string](nil)))); ok {
+		t.Fatalf("Max2: expected ok=false for an empty sequence")
+	}
+}
@@ -6,7 +6,9 @@ import (
 )
 
 // Min returns the smallest element produced by seq according to Go's
-// ordering for cmp.Ordered types. If seq is empty, ok is false.
+// ordering for cmp.Ordered types. As with slices.Min, if seq contains a
+// floating-point NaN the result is the first NaN. If seq is empty, ok is
+// false.
 func Min[T cmp.Ordered](seq iter.Seq[T]) (min T, ok bool) {
 	first := true
 	for item := range seq {
@@ -16,7 +18,7 @@ func Min[T cmp.Ordered](seq iter.Seq[T]) (min T, ok bool) {
 			ok = true
 			continue
 		}
-		if item < min {
+		if nanLess(item, min) {
 			min = item
 		}
 	}
@@ -40,3 +42,26 @@ func MinFunc[T any](seq iter.Seq[T], less func(a, b T) bool) (min T, ok bool) {
 	}
 	return
 }
+
+// Min2 returns the pair of seq2 with the smallest key. When several pairs
+// share the smallest key, the first is returned. A NaN key is treated as in
+// Min. If seq2 is empty, ok is false.
+func Min2[K cmp.Ordered, V any](seq2 iter.Seq2[K, V]) (key K, value V, ok bool) {
+	for k, v := range seq2 {
+		if !ok || nanLess(k, key) {
+			key, value, ok = k, v, true
+		}
+	}
+	return
+}
+
+// MinFunc2 returns the pair of seq2 that minimizes the provided less
+// function. If seq2 is empty, ok is false.
+func MinFunc2[K, V any](seq2 iter.Seq2[K, V], less func(k1 K, v1 V, k2 K, v2 V) bool) (key K, value V, ok bool) {
+	for k, v := range seq2 {
+		if !ok || less(k, v, key, value) {
+			key, value, ok = k, v, true
+		}
+	}
+	return
+}
@@ -93,3 +93,18 @@ func TestMinFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestMin2(t *testing.T) {
+	input := iters.Unpairs(slices.Values([]iters.Pair[int, string]{{2, "a"}, {1, "c"}, {1, "b"}, {3, "d"}}))
+
+	if k, v, ok := iters.Min2(input); !ok || k != 1 || v != "c" {
+		t.Fatalf("Min2: expected 1 c, got %v %v %v", k, v, ok)
+	}
+	byValue := func(_ int, a string, _ int, b string) bool { return a < b }
+	if k, v, ok := iters.MinFunc2(input, byValue); !ok || k != 2 || v != "a" {
+		t.Fatalf("MinFunc2: expected 2 a, got %v %v %v", k, v, ok)
+	}
+	if _, _, ok := iters.MinFunc2(iters.Unpairs(slices.Values([]iters.Pair[int, string](nil))), byValue); ok {
+		t.Fatalf("MinFunc2: expected ok=false for an empty sequence")
+	}
+}
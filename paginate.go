@@ -0,0 +1,139 @@
+package iters
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// PageFetcher fetches the page identified by token, returning its items and
+// the token of the following page. The zero token requests the first page,
+// and a zero next token marks the last page.
+type PageFetcher[T any, Tok comparable] = func(ctx context.Context, token Tok) (items []T, next Tok, err error)
+
+// Page is a single page produced by Pages.
+type Page[T any, Tok comparable] struct {
+	// Number is the 1-based position of the page.
+	Number int
+	// Token is the token the page was fetched with.
+	Token Tok
+	// Next is the token of the following page, or the zero value if this
+	// is the last page.
+	Next Tok
+	// Items holds the elements of the page.
+	Items []T
+}
+
+// PaginateOptions configures Pages and Paginate.
+type PaginateOptions struct {
+	// Prefetch fetches the next page concurrently while the current page is
+	// being consumed. If the consumer stops early, the in-flight fetch is
+	// canceled through its context and waited for before iteration returns.
+	Prefetch bool
+}
+
+// Pages returns a sequence of the pages produced by repeatedly calling fetch,
+// starting with the zero token and continuing until fetch returns a zero
+// next token. Pages are fetched lazily: without prefetching, no page is
+// requested until the consumer asks for it.
+//
+// An error from fetch, or the cancellation of ctx, is yielded once and ends
+// the sequence. A nil opts uses the defaults.
+func Pages[T any, Tok comparable](ctx context.Context, fetch PageFetcher[T, Tok], opts *PaginateOptions) iter.Seq2[Page[T, Tok], error] {
+	return func(yield func(Page[T, Tok], error) bool) {
+		if opts != nil && opts.Prefetch {
+			prefetchPages(ctx, fetch, yield)
+			return
+		}
+
+		var token, zero Tok
+		for number := 1; ; number++ {
+			if err := ctx.Err(); err != nil {
+				yield(Page[T, Tok]{Number: number, Token: token}, err)
+				return
+			}
+
+			items, next, err := fetch(ctx, token)
+			page := Page[T, Tok]{Number: number, Token: token, Next: next, Items: items}
+			if err != nil {
+				yield(page, err)
+				return
+			}
+			if !yield(page, nil) || next == zero {
+				return
+			}
+			token = next
+		}
+	}
+}
+
+// prefetchPages implements Pages with the next page fetched in the
+// background while the current one is yielded.
+func prefetchPages[T any, Tok comparable](ctx context.Context, fetch PageFetcher[T, Tok], yield func(Page[T, Tok], error) bool) {
+	type result struct {
+		page Page[T, Tok]
+		err  error
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := func(number int, token Tok) <-chan result {
+		ch := make(chan result, 1)
+		wg.Go(func() {
+			items, next, err := fetch(ctx, token)
+			ch <- result{Page[T, Tok]{Number: number, Token: token, Next: next, Items: items}, err}
+		})
+		return ch
+	}
+
+	var zero Tok
+	pending := start(1, zero)
+	for {
+		var r result
+		select {
+		case <-ctx.Done():
+			yield(Page[T, Tok]{}, ctx.Err())
+			return
+		case r = <-pending:
+		}
+
+		if r.err != nil {
+			yield(r.page, r.err)
+			return
+		}
+
+		last := r.page.Next == zero
+		if !last {
+			pending = start(r.page.Number+1, r.page.Next)
+		}
+		if !yield(r.page, nil) || last {
+			return
+		}
+	}
+}
+
+// Paginate returns a sequence of the items from every page produced by
+// fetch, as described by Pages. Stopping early, for example through Limit,
+// does not request any page beyond the one holding the last consumed item
+// unless prefetching is enabled. Errors are yielded with the zero value of
+// T and end the sequence.
+func Paginate[T any, Tok comparable](ctx context.Context, fetch PageFetcher[T, Tok], opts *PaginateOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for page, err := range Pages(ctx, fetch, opts) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
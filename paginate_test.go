@@ -0,0 +1,240 @@
+package iters_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/picatz/iters"
+)
+
+// pagedAPI serves pages of items keyed by a string token, the way many
+// "list" endpoints do.
+type pagedAPI struct {
+	pages   [][]int
+	failAt  int // 1-based page number that fails, or 0
+	fetches atomic.Int64
+}
+
+func (api *pagedAPI) fetch(ctx context.Context, token string) ([]int, string, error) {
+	api.fetches.Add(1)
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	index := 0
+	if token != "" {
+		var err error
+		if index, err = strconv.Atoi(token); err != nil {
+			return nil, "", err
+		}
+	}
+	if api.failAt == index+1 {
+		return nil, "", errors.New("page unavailable")
+	}
+
+	next := ""
+	if index+1 < len(api.pages) {
+		next = strconv.Itoa(index + 1)
+	}
+	return api.pages[index], next, nil
+}
+
+func ExamplePaginate() {
+	api := &pagedAPI{pages: [][]int{{1, 2}, {3, 4}, {5}}}
+
+	for item, err := range iters.Paginate(context.Background(), api.fetch, nil) {
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Print(item, " ")
+	}
+	fmt.Println()
+	// Output:
+	// 1 2 3 4 5
+}
+
+func ExamplePages() {
+	api := &pagedAPI{pages: [][]int{{1, 2}, {3, 4}, {5}}}
+
+	for page, err := range iters.Pages(context.Background(), api.fetch, nil) {
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("page %d token=%q next=%q items=%v\n", page.Number, page.Token, page.Next, page.Items)
+	}
+	// Output:
+	// page 1 token="" next="1" items=[1 2]
+	// page 2 token="1" next="2" items=[3 4]
+	// page 3 token="2" next="" items=[5]
+}
+
+type paginateTableTest struct {
+	name     string
+	pages    [][]int
+	opts     *iters.PaginateOptions
+	expected []int
+}
+
+func (test paginateTableTest) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		api := &pagedAPI{pages: test.pages}
+		got, err := iters.CollectErr(iters.Paginate(context.Background(), api.fetch, test.opts))
+		if err != nil {
+			t.Fatalf("Paginate: unexpected error %v", err)
+		}
+		if !slices.Equal(got, test.expected) {
+			t.Fatalf("Paginate: expected %v, got %v", test.expected, got)
+		}
+		if fetches := api.fetches.Load(); fetches != int64(len(test.pages)) {
+			t.Fatalf("Paginate: expected %d fetches, got %d", len(test.pages), fetches)
+		}
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []runnableTest{
+		paginateTableTest{
+			name:     "single page",
+			pages:    [][]int{{1, 2, 3}},
+			expected: []int{1, 2, 3},
+		},
+		paginateTableTest{
+			name:     "several pages",
+			pages:    [][]int{{1, 2}, {3}, {4, 5}},
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		paginateTableTest{
+			name:     "empty pages in between",
+			pages:    [][]int{{1}, {}, {2}},
+			expected: []int{1, 2},
+		},
+		paginateTableTest{
+			name:     "prefetch",
+			pages:    [][]int{{1, 2}, {3}, {4, 5}},
+			opts:     &iters.PaginateOptions{Prefetch: true},
+			expected: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestPaginateLimitFetchesNoExtraPages(t *testing.T) {
+	api := &pagedAPI{pages: [][]int{{1, 2}, {3, 4}, {5, 6}}}
+
+	got := slices.Collect(iters.Limit(iters.UntilErr(iters.Paginate(context.Background(), api.fetch, nil)), 4))
+	if want := []int{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if fetches := api.fetches.Load(); fetches != 2 {
+		t.Fatalf("expected 2 fetches, got %d", fetches)
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("prefetch=%t", prefetch), func(t *testing.T) {
+			api := &pagedAPI{pages: [][]int{{1, 2}, {3}, {4}}, failAt: 2}
+
+			got, err := iters.CollectErr(iters.Paginate(context.Background(), api.fetch, &iters.PaginateOptions{Prefetch: prefetch}))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if want := []int{1, 2}; !slices.Equal(got, want) {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+			if fetches := api.fetches.Load(); fetches != 2 {
+				t.Fatalf("expected 2 fetches, got %d", fetches)
+			}
+		})
+	}
+}
+
+func TestPaginateCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	api := &pagedAPI{pages: [][]int{{1}, {2}, {3}}}
+
+	var (
+		got []int
+		err error
+	)
+	for item, itemErr := range iters.Paginate(ctx, api.fetch, nil) {
+		if itemErr != nil {
+			err = itemErr
+			break
+		}
+		got = append(got, item)
+		cancel()
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if want := []int{1}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestPagesPrefetchOverlapsConsumer(t *testing.T) {
+	var (
+		fetched = make(chan string, 3)
+		pages   = map[string][]int{"": {1}, "a": {2}, "b": {3}}
+		nexts   = map[string]string{"": "a", "a": "b"}
+	)
+	fetch := func(ctx context.Context, token string) ([]int, string, error) {
+		fetched <- token
+		return pages[token], nexts[token], nil
+	}
+
+	var got []int
+	for page, err := range iters.Pages(context.Background(), fetch, &iters.PaginateOptions{Prefetch: true}) {
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		// The next page must be requested while this one is still being
+		// consumed. Receiving blocks until that happens.
+		if page.Next != "" {
+			for token := range fetched {
+				if token == page.Next {
+					break
+				}
+			}
+		}
+		got = append(got, page.Items...)
+	}
+
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestPagesPrefetchStopsEarly(t *testing.T) {
+	var canceled atomic.Bool
+	fetch := func(ctx context.Context, token int) ([]int, int, error) {
+		if token == 0 {
+			return []int{1}, 1, nil
+		}
+		<-ctx.Done()
+		canceled.Store(true)
+		return nil, 0, ctx.Err()
+	}
+
+	for range iters.Pages(context.Background(), fetch, &iters.PaginateOptions{Prefetch: true}) {
+		break
+	}
+
+	if !canceled.Load() {
+		t.Fatalf("expected the in-flight fetch to be canceled and waited for")
+	}
+}
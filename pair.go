@@ -0,0 +1,36 @@
+package iters
+
+import "iter"
+
+// Pair holds a key and a value, so that the pairs of an iter.Seq2 can be
+// handled as single values by combinators that work on iter.Seq.
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// Pairs returns a sequence of the key/value pairs of seq2 as Pair values.
+// Together with Unpairs it lets any combinator over iter.Seq run on keyed
+// data:
+//
+//	iters.Unpairs(iters.Filter(iters.Pairs(seq2), keep))
+func Pairs[K, V any](seq2 iter.Seq2[K, V]) iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range seq2 {
+			if !yield(Pair[K, V]{Key: k, Value: v}) {
+				return
+			}
+		}
+	}
+}
+
+// Unpairs reverses Pairs, yielding the key and value of each Pair in seq.
+func Unpairs[K, V any](seq iter.Seq[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range seq {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}
+}
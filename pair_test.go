@@ -0,0 +1,45 @@
+package iters_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func ExamplePairs() {
+	scores := maps.All(map[string]int{"ada": 3, "bob": 9, "cy": 5})
+
+	// Sort keyed data with a combinator written for iter.Seq.
+	byScore := func(a, b iters.Pair[string, int]) int { return b.Value - a.Value }
+	for name, score := range iters.Unpairs(iters.SortFunc(iters.Pairs(scores), byScore)) {
+		fmt.Println(name, score)
+	}
+	// Output:
+	// bob 9
+	// cy 5
+	// ada 3
+}
+
+func TestPairsRoundTrip(t *testing.T) {
+	input := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	pairs := slices.Collect(iters.Pairs(maps.All(input)))
+	if len(pairs) != len(input) {
+		t.Fatalf("Pairs: expected %d pairs, got %v", len(input), pairs)
+	}
+	for _, p := range pairs {
+		if input[p.Key] != p.Value {
+			t.Fatalf("Pairs: unexpected pair %v", p)
+		}
+	}
+	if got := maps.Collect(iters.Unpairs(slices.Values(pairs))); !maps.Equal(got, input) {
+		t.Fatalf("Unpairs: expected %v, got %v", input, got)
+	}
+
+	iterstest.CheckSeq(t, iters.Pairs(slices.All([]int{1, 2, 3})))
+	iterstest.CheckSeq2(t, iters.Unpairs(slices.Values(pairs)))
+}
@@ -0,0 +1,546 @@
+package iters
+
+import (
+	"cmp"
+	"container/heap"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Pipeline is a lazily evaluated chain of operations over a source
+// sequence. Unlike nesting Map, Filter and Limit, which wraps one closure
+// around another, a Pipeline records its stages as data. When the pipeline
+// is iterated the stages are first optimized and then run so that every
+// run of streaming stages (map, filter, limit and skip) between two sorts
+// is driven by a single loop over its input, each stage pushing elements
+// directly into the next.
+//
+// The optimizer applies the following rewrites:
+//
+//   - adjacent limits and adjacent skips are merged;
+//   - adjacent filters are fused into one predicate;
+//   - filters next to a map are fused into the map, so that they run as
+//     part of its stage rather than as stages of their own. Maps are not
+//     fused with each other, since each may change the element type, so
+//     every map remains a stage;
+//   - limits and skips are moved ahead of maps, so that mapped values that
+//     would be discarded are never computed;
+//   - filters are moved ahead of sorts, so that fewer values are sorted;
+//   - a sort followed by a limit becomes a bounded top-k selection.
+//
+// Explain describes the optimized plan. Sorting is stable, and the top-k
+// selection yields the same elements as sorting and then limiting, so the
+// rewrites do not change the result, only how many times the functions
+// passed to Map and Filter are called.
+//
+// A Pipeline is an immutable value; each method returns a new Pipeline.
+type Pipeline[T any] struct {
+	drive  func(head any)
+	stages []stage
+}
+
+// NewPipeline returns a Pipeline whose source is seq.
+func NewPipeline[T any](seq iter.Seq[T]) Pipeline[T] {
+	return Pipeline[T]{
+		drive: func(head any) {
+			s := head.(*sink[T])
+			for item := range seq {
+				if !s.push(item) {
+					break
+				}
+			}
+			s.flush()
+		},
+	}
+}
+
+// Filter adds a stage that keeps only the elements for which fn returns
+// true.
+func (p Pipeline[T]) Filter(fn Predicate[T]) Pipeline[T] {
+	return p.with(filterStage(fn))
+}
+
+// Limit adds a stage that keeps at most the first n elements.
+func (p Pipeline[T]) Limit(n int) Pipeline[T] {
+	return p.with(limitStage[T](max(n, 0)))
+}
+
+// After adds a stage that discards the first n elements.
+func (p Pipeline[T]) After(n int) Pipeline[T] {
+	return p.with(skipStage[T](max(n, 0)))
+}
+
+// SortFunc adds a stage that stably sorts the elements using cmp.
+func (p Pipeline[T]) SortFunc(cmp func(a, b T) int) Pipeline[T] {
+	return p.with(sortStage(cmp))
+}
+
+// PipelineMap adds a stage to p that replaces each element with fn(item).
+// It is a function rather than a method because it changes the element
+// type.
+func PipelineMap[T, R any](p Pipeline[T], fn Mapper[T, R]) Pipeline[R] {
+	return Pipeline[R]{drive: p.drive, stages: appendStage(p.stages, mapStage(fn))}
+}
+
+// PipelineSort adds a stage to p that sorts the elements in ascending
+// order.
+func PipelineSort[T cmp.Ordered](p Pipeline[T]) Pipeline[T] {
+	return p.SortFunc(cmp.Compare[T])
+}
+
+// Seq returns the sequence produced by the optimized pipeline.
+func (p Pipeline[T]) Seq() iter.Seq[T] {
+	stages := optimize(p.stages)
+	return func(yield func(T) bool) {
+		stopped := false
+		var next any = &sink[T]{
+			push: func(item T) bool {
+				if stopped || !yield(item) {
+					stopped = true
+					return false
+				}
+				return true
+			},
+			flush: func() {},
+		}
+		for i := len(stages) - 1; i >= 0; i-- {
+			next = stages[i].build(next)
+		}
+		p.drive(next)
+	}
+}
+
+// Explain describes the optimized plan, one line per loop or barrier, in
+// the order data flows through them.
+func (p Pipeline[T]) Explain() string {
+	var (
+		b    strings.Builder
+		loop []string
+	)
+	b.WriteString("source")
+	endLoop := func() {
+		if len(loop) > 0 {
+			fmt.Fprintf(&b, "\nloop: %s", strings.Join(loop, " -> "))
+			loop = loop[:0]
+		}
+	}
+	for _, s := range optimize(p.stages) {
+		if s.op.barrier() {
+			endLoop()
+			fmt.Fprintf(&b, "\n%s", s)
+			continue
+		}
+		loop = append(loop, s.String())
+	}
+	endLoop()
+	return b.String()
+}
+
+func (p Pipeline[T]) with(s stage) Pipeline[T] {
+	return Pipeline[T]{drive: p.drive, stages: appendStage(p.stages, s)}
+}
+
+// appendStage appends s to a copy of stages so that pipelines sharing a
+// prefix never overwrite each other's stages.
+func appendStage(stages []stage, s stage) []stage {
+	return append(slices.Clip(stages), s)
+}
+
+// sink receives the elements flowing out of a stage. push reports false
+// when no more elements are wanted, and flush is called once when the
+// input ends, whether or not push asked to stop.
+type sink[T any] struct {
+	push  func(T) bool
+	flush func()
+}
+
+type stageOp int
+
+const (
+	opMap stageOp = iota
+	opFilter
+	opLimit
+	opSkip
+	opSort
+	opTopK
+)
+
+// barrier reports whether the operation must see all of its input before
+// producing any output.
+func (op stageOp) barrier() bool {
+	return op == opSort || op == opTopK
+}
+
+// stage is a single operation of a Pipeline. It is type-erased so that
+// stages of different element types can live in one slice; the typed
+// closures are created by the generic constructors below.
+type stage struct {
+	op stageOp
+	n  int // limit, skip and top-k size
+
+	// build wraps the downstream *sink[Out] in a new *sink[In].
+	build func(next any) any
+
+	// limitIn and skipIn create a limit or skip over the stage's input
+	// type, so that the optimizer can move them ahead of the stage.
+	limitIn func(n int) stage
+	skipIn  func(n int) stage
+
+	// fuse combines two adjacent filters of the same type.
+	fuse func(next stage) stage
+	pred any
+
+	// absorb fuses a filter into a map stage, before or after its
+	// function. pre and post count the filters already fused on each side.
+	absorb    func(filter stage, before bool) stage
+	pre, post int
+
+	// topK converts a sort, or a larger top-k, into a bounded selection of
+	// n elements.
+	topK func(n int) stage
+}
+
+func (s stage) String() string {
+	switch s.op {
+	case opMap:
+		name := "map"
+		if s.pre > 0 {
+			name = filterName(s.pre) + "+" + name
+		}
+		if s.post > 0 {
+			name += "+" + filterName(s.post)
+		}
+		return name
+	case opFilter:
+		return filterName(s.n)
+	case opLimit:
+		return fmt.Sprintf("limit(%d)", s.n)
+	case opSkip:
+		return fmt.Sprintf("skip(%d)", s.n)
+	case opSort:
+		return "sort"
+	case opTopK:
+		return fmt.Sprintf("topk(%d)", s.n)
+	}
+	return "unknown"
+}
+
+// filterName describes n fused filters.
+func filterName(n int) string {
+	if n > 1 {
+		return fmt.Sprintf("filter(x%d)", n)
+	}
+	return "filter"
+}
+
+func mapStage[T, R any](fn Mapper[T, R]) stage {
+	return fusedMapStage[T, R](nil, fn, nil, 0, 0)
+}
+
+// fusedMapStage creates a map stage that also applies the filters fused
+// into it: pre, made of preN filters, runs before fn and post, made of
+// postN filters, after it. Either may be nil. A fused filter costs a call
+// to its predicate rather than a call into a separate stage.
+func fusedMapStage[T, R any](pre Predicate[T], fn Mapper[T, R], post Predicate[R], preN, postN int) stage {
+	return stage{
+		op:   opMap,
+		pre:  preN,
+		post: postN,
+		build: func(next any) any {
+			out := next.(*sink[R])
+			var push func(T) bool
+			switch {
+			case pre == nil && post == nil:
+				push = func(item T) bool { return out.push(fn(item)) }
+			case pre == nil:
+				push = func(item T) bool {
+					v := fn(item)
+					return !post(v) || out.push(v)
+				}
+			case post == nil:
+				push = func(item T) bool {
+					return !pre(item) || out.push(fn(item))
+				}
+			default:
+				push = func(item T) bool {
+					if !pre(item) {
+						return true
+					}
+					v := fn(item)
+					return !post(v) || out.push(v)
+				}
+			}
+			return &sink[T]{push: push, flush: out.flush}
+		},
+		limitIn: limitStage[T],
+		skipIn:  skipStage[T],
+		absorb: func(filter stage, before bool) stage {
+			if before {
+				p := filter.pred.(Predicate[T])
+				if pre != nil {
+					p = andPredicate(p, pre)
+				}
+				return fusedMapStage(p, fn, post, preN+filter.n, postN)
+			}
+			p := filter.pred.(Predicate[R])
+			if post != nil {
+				p = andPredicate(post, p)
+			}
+			return fusedMapStage(pre, fn, p, preN, postN+filter.n)
+		},
+	}
+}
+
+func filterStage[T any](fn Predicate[T]) stage {
+	return filterStageN(fn, 1)
+}
+
+// filterStageN creates a filter stage from n fused predicates.
+func filterStageN[T any](fn Predicate[T], n int) stage {
+	return stage{
+		op: opFilter,
+		n:  n,
+		build: func(next any) any {
+			out := next.(*sink[T])
+			return &sink[T]{
+				push: func(item T) bool {
+					if !fn(item) {
+						return true
+					}
+					return out.push(item)
+				},
+				flush: out.flush,
+			}
+		},
+		limitIn: limitStage[T],
+		skipIn:  skipStage[T],
+		pred:    fn,
+		fuse: func(next stage) stage {
+			return filterStageN(andPredicate(fn, next.pred.(Predicate[T])), n+next.n)
+		},
+	}
+}
+
+func andPredicate[T any](first, second Predicate[T]) Predicate[T] {
+	return func(item T) bool { return first(item) && second(item) }
+}
+
+func limitStage[T any](n int) stage {
+	return stage{
+		op: opLimit,
+		n:  n,
+		build: func(next any) any {
+			out := next.(*sink[T])
+			count := 0
+			return &sink[T]{
+				push: func(item T) bool {
+					if count >= n {
+						return false
+					}
+					count++
+					return out.push(item) && count < n
+				},
+				flush: out.flush,
+			}
+		},
+		limitIn: limitStage[T],
+		skipIn:  skipStage[T],
+	}
+}
+
+func skipStage[T any](n int) stage {
+	return stage{
+		op: opSkip,
+		n:  n,
+		build: func(next any) any {
+			out := next.(*sink[T])
+			skipped := 0
+			return &sink[T]{
+				push: func(item T) bool {
+					if skipped < n {
+						skipped++
+						return true
+					}
+					return out.push(item)
+				},
+				flush: out.flush,
+			}
+		},
+		limitIn: limitStage[T],
+		skipIn:  skipStage[T],
+	}
+}
+
+// ranked pairs an element with its input position so that sorting and
+// top-k selection break ties by arrival order.
+type ranked[T any] struct {
+	item  T
+	index int
+}
+
+func rankedCompare[T any](cmp func(a, b T) int) func(a, b ranked[T]) int {
+	return func(a, b ranked[T]) int {
+		if c := cmp(a.item, b.item); c != 0 {
+			return c
+		}
+		return a.index - b.index
+	}
+}
+
+// emit pushes the sorted items to out and then flushes it.
+func emit[T any](items []ranked[T], out *sink[T]) {
+	for _, r := range items {
+		if !out.push(r.item) {
+			break
+		}
+	}
+	out.flush()
+}
+
+func sortStage[T any](cmp func(a, b T) int) stage {
+	return stage{
+		op: opSort,
+		build: func(next any) any {
+			out := next.(*sink[T])
+			var items []ranked[T]
+			return &sink[T]{
+				push: func(item T) bool {
+					items = append(items, ranked[T]{item, len(items)})
+					return true
+				},
+				flush: func() {
+					slices.SortFunc(items, rankedCompare(cmp))
+					emit(items, out)
+				},
+			}
+		},
+		limitIn: limitStage[T],
+		skipIn:  skipStage[T],
+		topK: func(n int) stage {
+			return topKStage(cmp, n)
+		},
+	}
+}
+
+func topKStage[T any](cmp func(a, b T) int, n int) stage {
+	return stage{
+		op: opTopK,
+		n:  n,
+		build: func(next any) any {
+			out := next.(*sink[T])
+			h := &topKHeap[T]{cmp: rankedCompare(cmp)}
+			seen := 0
+			return &sink[T]{
+				push: func(item T) bool {
+					r := ranked[T]{item, seen}
+					seen++
+					switch {
+					case n == 0:
+					case len(h.items) < n:
+						heap.Push(h, r)
+					case h.cmp(r, h.items[0]) < 0:
+						h.items[0] = r
+						heap.Fix(h, 0)
+					}
+					return true
+				},
+				flush: func() {
+					slices.SortFunc(h.items, h.cmp)
+					emit(h.items, out)
+				},
+			}
+		},
+		limitIn: limitStage[T],
+		skipIn:  skipStage[T],
+		topK: func(n int) stage {
+			return topKStage(cmp, n)
+		},
+	}
+}
+
+// topKHeap is a max-heap holding the n smallest elements seen so far, with
+// the largest of them at the root.
+type topKHeap[T any] struct {
+	items []ranked[T]
+	cmp   func(a, b ranked[T]) int
+}
+
+func (h *topKHeap[T]) Len() int           { return len(h.items) }
+func (h *topKHeap[T]) Less(i, j int) bool { return h.cmp(h.items[i], h.items[j]) > 0 }
+func (h *topKHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *topKHeap[T]) Push(x any)         { h.items = append(h.items, x.(ranked[T])) }
+func (h *topKHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// optimize rewrites stages until no rule applies, returning a new slice.
+func optimize(stages []stage) []stage {
+	out := slices.Clone(stages)
+	for changed := true; changed; {
+		changed = false
+		for i := 0; i+1 < len(out); i++ {
+			a, b := out[i], out[i+1]
+			switch {
+			case a.op == opLimit && b.op == opLimit:
+				a.n = min(a.n, b.n)
+				out = slices.Replace(out, i, i+2, a.limitIn(a.n))
+			case a.op == opSkip && b.op == opSkip:
+				out = slices.Replace(out, i, i+2, a.skipIn(addSaturating(a.n, b.n)))
+			case a.op == opFilter && b.op == opFilter:
+				out = slices.Replace(out, i, i+2, a.fuse(b))
+			case a.op == opSkip && b.op == opLimit:
+				// Skipping a then taking b is taking a+b then skipping a,
+				// which exposes the limit to the rules below.
+				out[i], out[i+1] = a.limitIn(addSaturating(a.n, b.n)), a.skipIn(a.n)
+			case a.op == opMap && b.op == opLimit:
+				out[i], out[i+1] = a.limitIn(b.n), a
+			case a.op == opMap && b.op == opSkip:
+				out[i], out[i+1] = a.skipIn(b.n), a
+			case a.op == opSort && b.op == opFilter:
+				out[i], out[i+1] = b, a
+			case a.op == opSort && b.op == opLimit:
+				out[i] = a.topK(b.n)
+				out = slices.Delete(out, i+1, i+2)
+			case a.op == opTopK && b.op == opLimit:
+				out[i] = a.topK(min(a.n, b.n))
+				out = slices.Delete(out, i+1, i+2)
+			default:
+				continue
+			}
+			changed = true
+		}
+	}
+	return fuseMaps(out)
+}
+
+// fuseMaps folds every filter next to a map into that map. It runs after
+// the other rewrites, which move limits and skips past unfused maps only.
+func fuseMaps(stages []stage) []stage {
+	for i := 0; i+1 < len(stages); {
+		a, b := stages[i], stages[i+1]
+		switch {
+		case a.op == opMap && b.op == opFilter:
+			stages = slices.Replace(stages, i, i+2, a.absorb(b, false))
+		case a.op == opFilter && b.op == opMap:
+			stages = slices.Replace(stages, i, i+2, b.absorb(a, true))
+		default:
+			i++
+		}
+	}
+	return stages
+}
+
+// addSaturating returns a+b for non-negative counts, or math.MaxInt if the
+// sum overflows. No input can be longer than math.MaxInt, so a saturated
+// limit or skip behaves like the exact one.
+func addSaturating(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
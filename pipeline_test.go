@@ -0,0 +1,339 @@
+package iters_test
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/picatz/iters"
+)
+
+func ExamplePipeline() {
+	type Animal struct {
+		Name string
+		Legs int
+	}
+
+	animals := []Animal{
+		{"cat", 4},
+		{"spider", 8},
+		{"fish", 0},
+		{"bird", 2},
+		{"dog", 4},
+	}
+
+	p := iters.PipelineMap(
+		iters.NewPipeline(slices.Values(animals)).
+			Filter(func(a Animal) bool { return a.Legs > 0 }).
+			SortFunc(func(a, b Animal) int { return cmp.Compare(b.Legs, a.Legs) }).
+			Limit(2),
+		func(a Animal) string { return a.Name },
+	)
+
+	fmt.Println(slices.Collect(p.Seq()))
+	fmt.Println(p.Explain())
+	// Output:
+	// [spider cat]
+	// source
+	// loop: filter
+	// topk(2)
+	// loop: map
+}
+
+func ExamplePipeline_Explain() {
+	p := iters.NewPipeline(iters.Repeat(1))
+	p = p.Filter(func(n int) bool { return n > 0 })
+	p = p.Filter(func(n int) bool { return n < 10 })
+	q := iters.PipelineMap(p, strconv.Itoa).After(2).Limit(3)
+
+	fmt.Println(q.Explain())
+	// Output:
+	// source
+	// loop: filter(x2) -> limit(5) -> skip(2) -> map
+}
+
+// pipelineTableTest compares a Pipeline against the equivalent nested
+// combinators.
+type pipelineTableTest struct {
+	name     string
+	pipeline iters.Pipeline[int]
+	nested   func() []int
+	plan     string
+}
+
+func (test pipelineTableTest) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		got := slices.Collect(test.pipeline.Seq())
+		want := test.nested()
+		if !slices.Equal(got, want) {
+			t.Fatalf("Pipeline: expected %v, got %v", want, got)
+		}
+		if plan := test.pipeline.Explain(); plan != test.plan {
+			t.Fatalf("Explain: expected\n%s\ngot\n%s", test.plan, plan)
+		}
+	})
+}
+
+func TestPipeline(t *testing.T) {
+	input := []int{9, 3, 7, 1, 8, 2, 6, 4, 5, 3, 7}
+	source := func() iters.Pipeline[int] { return iters.NewPipeline(slices.Values(input)) }
+	double := func(n int) int { return n * 2 }
+	odd := func(n int) bool { return n%2 == 1 }
+	desc := func(a, b int) int { return cmp.Compare(b, a) }
+
+	tests := []runnableTest{
+		pipelineTableTest{
+			name:     "no stages",
+			pipeline: source(),
+			nested:   func() []int { return input },
+			plan:     "source",
+		},
+		pipelineTableTest{
+			name:     "map then limit pushes the limit down",
+			pipeline: iters.PipelineMap(source(), double).Limit(3),
+			nested: func() []int {
+				return slices.Collect(iters.Limit(iters.Map(slices.Values(input), double), 3))
+			},
+			plan: "source\nloop: limit(3) -> map",
+		},
+		pipelineTableTest{
+			name:     "limit is not moved ahead of a filter",
+			pipeline: source().Filter(odd).Limit(2),
+			nested: func() []int {
+				return slices.Collect(iters.Limit(iters.Filter(slices.Values(input), odd), 2))
+			},
+			plan: "source\nloop: filter -> limit(2)",
+		},
+		pipelineTableTest{
+			name:     "adjacent limits and skips merge",
+			pipeline: source().Limit(8).Limit(5).After(1).After(1),
+			nested: func() []int {
+				return slices.Collect(iters.After(iters.After(iters.Limit(iters.Limit(slices.Values(input), 8), 5), 1), 1))
+			},
+			plan: "source\nloop: limit(5) -> skip(2)",
+		},
+		pipelineTableTest{
+			name:     "skip then limit",
+			pipeline: source().After(2).Limit(3),
+			nested: func() []int {
+				return slices.Collect(iters.Limit(iters.After(slices.Values(input), 2), 3))
+			},
+			plan: "source\nloop: limit(5) -> skip(2)",
+		},
+		pipelineTableTest{
+			name:     "skip then unbounded limit saturates",
+			pipeline: source().After(2).Limit(math.MaxInt),
+			nested: func() []int {
+				return slices.Collect(iters.Limit(iters.After(slices.Values(input), 2), math.MaxInt))
+			},
+			plan: fmt.Sprintf("source\nloop: limit(%d) -> skip(2)", math.MaxInt),
+		},
+		pipelineTableTest{
+			name:     "merged skips saturate",
+			pipeline: source().After(math.MaxInt).After(1),
+			nested: func() []int {
+				return slices.Collect(iters.After(iters.After(slices.Values(input), math.MaxInt), 1))
+			},
+			plan: fmt.Sprintf("source\nloop: skip(%d)", math.MaxInt),
+		},
+		pipelineTableTest{
+			name:     "sort then limit becomes top-k",
+			pipeline: source().SortFunc(desc).Limit(4),
+			nested: func() []int {
+				return slices.Collect(iters.Limit(iters.SortFunc(slices.Values(input), desc), 4))
+			},
+			plan: "source\ntopk(4)",
+		},
+		pipelineTableTest{
+			name:     "sort, skip and limit",
+			pipeline: iters.PipelineSort(source()).After(3).Limit(2),
+			nested: func() []int {
+				return slices.Collect(iters.Limit(iters.After(iters.Sort(slices.Values(input)), 3), 2))
+			},
+			plan: "source\ntopk(5)\nloop: skip(3)",
+		},
+		pipelineTableTest{
+			name:     "sort, map and limit",
+			pipeline: iters.PipelineMap(iters.PipelineSort(source()), double).Limit(3),
+			nested: func() []int {
+				return slices.Collect(iters.Limit(iters.Map(iters.Sort(slices.Values(input)), double), 3))
+			},
+			plan: "source\ntopk(3)\nloop: map",
+		},
+		pipelineTableTest{
+			name:     "filter moves ahead of sort and fuses",
+			pipeline: source().Filter(odd).SortFunc(desc).Filter(func(n int) bool { return n > 3 }),
+			nested: func() []int {
+				return slices.Collect(iters.Filter(iters.SortFunc(iters.Filter(slices.Values(input), odd), desc), func(n int) bool { return n > 3 }))
+			},
+			plan: "source\nloop: filter(x2)\nsort",
+		},
+		pipelineTableTest{
+			name:     "filters fuse into maps",
+			pipeline: iters.PipelineMap(source().Filter(odd).Filter(func(n int) bool { return n > 1 }), double).Filter(func(n int) bool { return n < 15 }),
+			nested: func() []int {
+				return slices.Collect(iters.Filter(iters.Map(iters.Filter(iters.Filter(slices.Values(input), odd), func(n int) bool { return n > 1 }), double), func(n int) bool { return n < 15 }))
+			},
+			plan: "source\nloop: filter(x2)+map+filter",
+		},
+		pipelineTableTest{
+			name:     "limit stays between a map and a filter",
+			pipeline: iters.PipelineMap(source(), double).Limit(4).Filter(func(n int) bool { return n > 10 }),
+			nested: func() []int {
+				return slices.Collect(iters.Filter(iters.Limit(iters.Map(slices.Values(input), double), 4), func(n int) bool { return n > 10 }))
+			},
+			plan: "source\nloop: limit(4) -> map+filter",
+		},
+		pipelineTableTest{
+			name:     "limit zero",
+			pipeline: iters.PipelineSort(source()).Limit(0),
+			nested:   func() []int { return nil },
+			plan:     "source\ntopk(0)",
+		},
+		pipelineTableTest{
+			name:     "limit larger than input",
+			pipeline: iters.PipelineSort(source()).Limit(100),
+			nested: func() []int {
+				return slices.Collect(iters.Sort(slices.Values(input)))
+			},
+			plan: "source\ntopk(100)",
+		},
+	}
+
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestPipelineCallsMapLazily(t *testing.T) {
+	calls := 0
+	p := iters.PipelineMap(iters.NewPipeline(iters.Repeat(1)), func(n int) int {
+		calls++
+		return n
+	}).After(2).Limit(3)
+
+	if got := slices.Collect(p.Seq()); len(got) != 3 {
+		t.Fatalf("expected 3 values, got %v", got)
+	}
+	if calls != 3 {
+		t.Fatalf("expected map to be called 3 times, got %d", calls)
+	}
+}
+
+func TestPipelineTopKIsStable(t *testing.T) {
+	type item struct {
+		key, id int
+	}
+
+	var input []item
+	for i := range 200 {
+		input = append(input, item{key: rand.IntN(10), id: i})
+	}
+	byKey := func(a, b item) int { return cmp.Compare(a.key, b.key) }
+
+	for _, k := range []int{1, 5, 37, 200} {
+		got := slices.Collect(iters.NewPipeline(slices.Values(input)).SortFunc(byKey).Limit(k).Seq())
+
+		want := slices.Clone(input)
+		slices.SortStableFunc(want, byKey)
+		if !slices.Equal(got, want[:k]) {
+			t.Fatalf("k=%d: expected %v, got %v", k, want[:k], got)
+		}
+	}
+}
+
+func TestPipelineStopsEarly(t *testing.T) {
+	p := iters.PipelineMap(iters.PipelineSort(iters.NewPipeline(slices.Values([]int{3, 1, 2}))), strconv.Itoa)
+
+	var got []string
+	for s := range p.Seq() {
+		got = append(got, s)
+		break
+	}
+	if want := []string{"1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestPipelineIsImmutable(t *testing.T) {
+	base := iters.NewPipeline(slices.Values([]int{1, 2, 3, 4})).Filter(func(n int) bool { return n > 1 })
+	a := base.Limit(1)
+	b := base.After(1)
+
+	if got := slices.Collect(a.Seq()); !slices.Equal(got, []int{2}) {
+		t.Fatalf("expected [2], got %v", got)
+	}
+	if got := slices.Collect(b.Seq()); !slices.Equal(got, []int{3, 4}) {
+		t.Fatalf("expected [3 4], got %v", got)
+	}
+	if got := slices.Collect(base.Seq()); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Fatalf("expected [2 3 4], got %v", got)
+	}
+}
+
+var benchmarkInput = func() []int {
+	r := rand.New(rand.NewPCG(1, 2))
+	input := make([]int, 100_000)
+	for i := range input {
+		input[i] = r.IntN(1_000_000)
+	}
+	return input
+}()
+
+func BenchmarkMapFilterChain(b *testing.B) {
+	inc := func(n int) int { return n + 1 }
+	even := func(n int) bool { return n%2 == 0 }
+
+	b.Run("nested", func(b *testing.B) {
+		for b.Loop() {
+			seq := iters.Filter(iters.Map(iters.Filter(iters.Map(slices.Values(benchmarkInput), inc), even), inc), even)
+			for range seq {
+			}
+		}
+	})
+	b.Run("pipeline", func(b *testing.B) {
+		p := iters.PipelineMap(iters.PipelineMap(iters.NewPipeline(slices.Values(benchmarkInput)), inc).Filter(even), inc).Filter(even)
+		for b.Loop() {
+			for range p.Seq() {
+			}
+		}
+	})
+}
+
+func BenchmarkMapLimit(b *testing.B) {
+	format := func(n int) string { return strconv.Itoa(n) }
+
+	b.Run("nested", func(b *testing.B) {
+		for b.Loop() {
+			for range iters.Limit(iters.After(iters.Map(slices.Values(benchmarkInput), format), 50_000), 10) {
+			}
+		}
+	})
+	b.Run("pipeline", func(b *testing.B) {
+		p := iters.PipelineMap(iters.NewPipeline(slices.Values(benchmarkInput)), format).After(50_000).Limit(10)
+		for b.Loop() {
+			for range p.Seq() {
+			}
+		}
+	})
+}
+
+func BenchmarkSortLimit(b *testing.B) {
+	b.Run("nested", func(b *testing.B) {
+		for b.Loop() {
+			for range iters.Limit(iters.Sort(slices.Values(benchmarkInput)), 10) {
+			}
+		}
+	})
+	b.Run("pipeline", func(b *testing.B) {
+		p := iters.PipelineSort(iters.NewPipeline(slices.Values(benchmarkInput))).Limit(10)
+		for b.Loop() {
+			for range p.Seq() {
+			}
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package iters
+
+import "iter"
+
+// Range returns a sequence of numbers from start up to, but not including,
+// end, advancing by step. A negative step counts down towards end. When
+// step is zero or NaN, or start is already at or past end in the direction
+// of step, nothing is produced.
+//
+// Floating-point values are computed as start + i*step rather than by
+// repeated addition, so rounding errors do not accumulate over long
+// ranges. Integer ranges stop rather than wrap around when the next value
+// would overflow T.
+func Range[T Number](start, end, step T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if !(step > 0 || step < 0) {
+			return
+		}
+		if isFloat[T]() {
+			for i := 0; ; i++ {
+				v := start + T(i)*step
+				if step > 0 && !(v < end) || step < 0 && !(v > end) {
+					return
+				}
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		for v := start; step > 0 && v < end || step < 0 && v > end; {
+			if !yield(v) {
+				return
+			}
+			next := v + step
+			if (next > v) != (step > 0) {
+				return // overflow
+			}
+			v = next
+		}
+	}
+}
+
+// CountFrom returns an infinite sequence of the numbers start, start+1,
+// start+2, and so on. Integer counters wrap around on overflow; floating
+// point counters are computed as start + i, so they do not drift.
+func CountFrom[T Number](start T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := T(0); ; i++ {
+			if !yield(start + i) {
+				return
+			}
+		}
+	}
+}
+
+// isFloat reports whether T is a floating-point type.
+func isFloat[T Number]() bool {
+	one := T(1)
+	return one/(one+one) != 0
+}
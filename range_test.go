@@ -0,0 +1,118 @@
+package iters_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleRange() {
+	fmt.Println(slices.Collect(iters.Range(0, 10, 3)))
+	fmt.Println(slices.Collect(iters.Range(5, 0, -2)))
+	fmt.Println(slices.Collect(iters.Range(0, 0.5, 0.1)))
+	// Output:
+	// [0 3 6 9]
+	// [5 3 1]
+	// [0 0.1 0.2 0.30000000000000004 0.4]
+}
+
+func ExampleCountFrom() {
+	fmt.Println(slices.Collect(iters.Limit(iters.CountFrom(10), 3)))
+	// Output:
+	// [10 11 12]
+}
+
+type rangeTableTest[T iters.Number] struct {
+	name             string
+	start, end, step T
+	expected         []T
+}
+
+func (test rangeTableTest[T]) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		got := slices.Collect(iters.Range(test.start, test.end, test.step))
+		if !slices.Equal(got, test.expected) {
+			t.Fatalf("Range: expected %v, got %v", test.expected, got)
+		}
+	})
+}
+
+func TestRange(t *testing.T) {
+	tests := []runnableTest{
+		rangeTableTest[int]{name: "ascending", start: 1, end: 4, step: 1, expected: []int{1, 2, 3}},
+		rangeTableTest[int]{name: "descending", start: 3, end: -3, step: -2, expected: []int{3, 1, -1}},
+		rangeTableTest[int]{name: "empty", start: 4, end: 1, step: 1},
+		rangeTableTest[int]{name: "zero step", start: 0, end: 10, step: 0},
+		rangeTableTest[int8]{name: "stops before overflow", start: 100, end: 127, step: 20, expected: []int8{100, 120}},
+		rangeTableTest[int8]{name: "stops before underflow", start: -100, end: -128, step: -20, expected: []int8{-100, -120}},
+		rangeTableTest[uint8]{name: "unsigned up to max", start: 250, end: 255, step: 3, expected: []uint8{250, 253}},
+		rangeTableTest[float64]{name: "descending floats", start: 1, end: 0, step: -0.25, expected: []float64{1, 0.75, 0.5, 0.25}},
+		rangeTableTest[float64]{name: "nan step", start: 0, end: 1, step: math.NaN()},
+		rangeTableTest[float64]{name: "nan end", start: 0, end: math.NaN(), step: 1},
+		rangeTableTest[float32]{name: "float32", start: 0, end: 1, step: 0.5, expected: []float32{0, 0.5}},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestRangeFloatsDoNotDrift(t *testing.T) {
+	step := 0.1
+	n := 0
+	for v := range iters.Range(0, 1000, step) {
+		if want := float64(n) * step; v != want {
+			t.Fatalf("Range: expected value %d to be %v, got %v", n, want, v)
+		}
+		n++
+	}
+	if n != 10000 {
+		t.Fatalf("Range: expected 10000 values, got %d", n)
+	}
+}
+
+type rangeArgs struct {
+	start, end, step int
+}
+
+func TestRangeMatchesLoop(t *testing.T) {
+	small := gen.Int(-50, 50)
+	args := func(r *rand.Rand) rangeArgs {
+		return rangeArgs{small(r), small(r), gen.Int(-7, 7)(r)}
+	}
+
+	gen.ForAll(t, args, func(a rangeArgs) bool {
+		var want []int
+		switch {
+		case a.step > 0:
+			for i := a.start; i < a.end; i += a.step {
+				want = append(want, i)
+			}
+		case a.step < 0:
+			for i := a.start; i > a.end; i += a.step {
+				want = append(want, i)
+			}
+		}
+		return slices.Equal(slices.Collect(iters.Range(a.start, a.end, a.step)), want)
+	})
+}
+
+func TestCountFromMatchesLoop(t *testing.T) {
+	gen.ForAll(t, gen.Int(-1000, 1000), func(start int) bool {
+		got := slices.Collect(iters.Limit(iters.CountFrom(start), 20))
+		for i, v := range got {
+			if v != start+i {
+				return false
+			}
+		}
+		return len(got) == 20
+	})
+
+	iterstest.CheckSeq(t, iters.CountFrom(1.5))
+	iterstest.CheckSeq(t, iters.Range(0, 10, 2))
+}
@@ -36,3 +36,39 @@ func RepeatN[T any](value T, count int) iter.Seq[T] {
 		}
 	}
 }
+
+// Repeat2 is the keyed companion to Repeat; it keeps yielding the pair key,
+// value until the consumer stops iteration.
+func Repeat2[K, V any](key K, value V) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// RepeatFunc2 returns an infinite sequence that yields the pair returned by
+// fn on every iteration.
+func RepeatFunc2[K, V any](fn func() (K, V)) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for {
+			if !yield(fn()) {
+				return
+			}
+		}
+	}
+}
+
+// RepeatN2 yields the pair key, value count times. When count <= 0 nothing
+// is produced.
+func RepeatN2[K, V any](key K, value V, count int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for range count {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
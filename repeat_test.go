@@ -131,3 +131,23 @@ func TestRepeatN(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestRepeat2(t *testing.T) {
+	want := []iters.Pair[string, int]{{"a", 1}, {"a", 1}, {"a", 1}}
+	if got := slices.Collect(iters.Pairs(iters.Limit2(iters.Repeat2("a", 1), 3))); !slices.Equal(got, want) {
+		t.Fatalf("Repeat2: expected %v, got %v", want, got)
+	}
+	if got := slices.Collect(iters.Pairs(iters.RepeatN2("a", 1, 3))); !slices.Equal(got, want) {
+		t.Fatalf("RepeatN2: expected %v, got %v", want, got)
+	}
+	if got := slices.Collect(iters.Pairs(iters.RepeatN2("a", 1, -1))); len(got) != 0 {
+		t.Fatalf("RepeatN2: expected nothing for a negative count, got %v", got)
+	}
+
+	n := 0
+	counter := iters.RepeatFunc2(func() (int, int) { n++; return n, n * n })
+	want2 := []iters.Pair[int, int]{{1, 1}, {2, 4}}
+	if got := slices.Collect(iters.Pairs(iters.Limit2(counter, 2))); !slices.Equal(got, want2) {
+		t.Fatalf("RepeatFunc2: expected %v, got %v", want2, got)
+	}
+}
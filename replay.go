@@ -0,0 +1,86 @@
+package iters
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// Replay returns a sequence that shares a single pass over seq between its
+// iterations while keeping only the last n elements in memory. Like
+// [Reusable], each element is pulled from seq once, through one cursor,
+// and only as far as the furthest iteration has read. An iteration starts
+// with the (at most n) most recently pulled elements and then continues
+// with elements pulled for it or by concurrent iterations. An iteration
+// that falls more than n elements behind skips ahead to the oldest
+// element still buffered. When n <= 0 nothing is buffered and each
+// iteration only sees elements pulled after it started.
+//
+// Replay is safe for concurrent use. Until seq is exhausted the cursor
+// holds it suspended; an abandoned Replay releases it when it is garbage
+// collected.
+func Replay[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	r := &replay[T]{seq: seq, cur: new(cursor[func() (T, bool)]), buf: make([]T, max(n, 0))}
+	runtime.AddCleanup(r, (*cursor[func() (T, bool)]).release, r.cur)
+
+	return func(yield func(T) bool) {
+		pos := r.start()
+		for {
+			item, ok := r.at(&pos)
+			if !ok || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// replay is the state shared by the iterations of a Replay sequence. Item
+// i of the source lives in buf[i%len(buf)] while pulled-len(buf) <= i <
+// pulled.
+type replay[T any] struct {
+	mu     sync.Mutex
+	seq    iter.Seq[T]
+	cur    *cursor[func() (T, bool)]
+	buf    []T
+	pulled int
+	done   bool
+}
+
+// start returns the index of the oldest buffered element.
+func (r *replay[T]) start() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return max(r.pulled-len(r.buf), 0)
+}
+
+// at returns the element at *pos, pulling it if needed, and advances *pos
+// past it. Positions that have been evicted are moved forward first.
+func (r *replay[T]) at(pos *int) (item T, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	*pos = max(*pos, r.pulled-len(r.buf))
+	if *pos < r.pulled {
+		item = r.buf[*pos%len(r.buf)]
+		*pos++
+		return item, true
+	}
+	if r.done {
+		return item, false
+	}
+	if r.cur.next == nil {
+		r.cur.next, r.cur.stop = iter.Pull(r.seq)
+	}
+	item, ok = r.cur.next()
+	if !ok {
+		r.cur.release()
+		r.done = true
+		return item, false
+	}
+	if len(r.buf) > 0 {
+		r.buf[r.pulled%len(r.buf)] = item
+	}
+	r.pulled++
+	*pos = r.pulled
+	return item, true
+}
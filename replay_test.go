@@ -0,0 +1,114 @@
+package iters_test
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func ExampleReplay() {
+	replay := iters.Replay(slices.Values([]int{1, 2, 3, 4, 5}), 2)
+
+	for n := range replay {
+		if n == 3 {
+			break
+		}
+	}
+
+	// A late iteration starts with the last two elements pulled so far.
+	fmt.Println(slices.Collect(replay))
+	fmt.Println(slices.Collect(replay))
+	// Output:
+	// [2 3 4 5]
+	// [4 5]
+}
+
+type replayTableTest[T comparable] struct {
+	name   string
+	input  []T
+	n      int
+	first  int
+	passes [][]T
+}
+
+func (test replayTableTest[T]) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		src, c := iterstest.Counting(slices.Values(test.input))
+		replay := iters.Replay(src, test.n)
+
+		for range iters.Limit(replay, test.first) {
+		}
+		for i, want := range test.passes {
+			if got := slices.Collect(replay); !slices.Equal(got, want) {
+				t.Fatalf("Replay: pass %d expected %v, got %v", i, want, got)
+			}
+		}
+		if c.Iterations() > 1 || c.Pulled() > len(test.input) {
+			t.Fatalf("Replay: expected a single pass over the source, got %d iterations pulling %d", c.Iterations(), c.Pulled())
+		}
+	})
+}
+
+func TestReplay(t *testing.T) {
+	tests := []runnableTest{
+		replayTableTest[int]{name: "buffer larger than input", input: []int{1, 2, 3}, n: 10, first: 1, passes: [][]int{{1, 2, 3}, {1, 2, 3}}},
+		replayTableTest[int]{name: "last n", input: []int{1, 2, 3, 4}, n: 2, first: 0, passes: [][]int{{1, 2, 3, 4}, {3, 4}, {3, 4}}},
+		replayTableTest[int]{name: "resumes after early stop", input: []int{1, 2, 3, 4}, n: 1, first: 2, passes: [][]int{{2, 3, 4}, {4}}},
+		replayTableTest[int]{name: "no buffer", input: []int{1, 2, 3}, n: 0, first: 1, passes: [][]int{{2, 3}, nil}},
+		replayTableTest[string]{name: "empty", input: nil, n: 3, first: 1, passes: [][]string{nil, nil}},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestReplayConcurrentConsumers(t *testing.T) {
+	src, c := iterstest.Counting(iters.Limit(iterstest.Naturals(), 500))
+	replay := iters.Replay(src, 1000)
+
+	var wg sync.WaitGroup
+	results := make([][]int, 4)
+	for i := range results {
+		wg.Go(func() {
+			results[i] = slices.Collect(replay)
+		})
+	}
+	wg.Wait()
+
+	for i, got := range results {
+		if len(got) != 500 || !slices.IsSorted(got) {
+			t.Fatalf("Replay: consumer %d saw %d elements", i, len(got))
+		}
+	}
+	if c.Pulled() != 500 {
+		t.Fatalf("Replay: expected 500 pulled, got %d", c.Pulled())
+	}
+}
+
+func TestReplaySingleUseSource(t *testing.T) {
+	replay := iters.Replay(chanSeq(6), 10)
+	for n := range replay {
+		if n == 1 {
+			break
+		}
+	}
+	if got := slices.Collect(replay); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5}) {
+		t.Fatalf("Replay: expected [0 1 2 3 4 5] after an early stop, got %v", got)
+	}
+}
+
+func TestReplayReleasedWhenUnreachable(t *testing.T) {
+	iterstest.VerifyNoLeaks(t)
+
+	for range 10 {
+		for range iters.Replay(iterstest.Naturals(), 3) {
+			break
+		}
+	}
+	runtime.GC()
+}
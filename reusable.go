@@ -1,66 +1,215 @@
 package iters
 
-import "iter"
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
 
 // Reusable caches every element pulled from seq so that the returned
-// sequence can be iterated multiple times. The cache grows until seq is
-// exhausted, so using Reusable with an unbounded input can consume
-// unbounded memory.
+// sequence can be iterated multiple times. It is shorthand for
+// NewCache(seq).All(); use a [Cache] directly to inspect or reset the
+// cache. The cache grows until seq is exhausted, so using Reusable with an
+// unbounded input can consume unbounded memory. A partly read Reusable
+// holds seq suspended until it is garbage collected.
 func Reusable[T any](seq iter.Seq[T]) iter.Seq[T] {
-	var cache []T
-	var done bool
+	return NewCache(seq).All()
+}
+
+// Reusable2 caches each key/value pair pulled from seq2 so that the result
+// can be iterated multiple times. It is shorthand for NewCache2(seq2).All()
+// and shares the same memory trade-offs as Reusable.
+func Reusable2[K, V any](seq2 iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return NewCache2(seq2).All()
+}
+
+// Cache memoizes the elements of a sequence. The source is read through a
+// single iter.Pull cursor, and only as far as the furthest consumer has
+// read: an iteration that stops early leaves the cursor where it is, and
+// later iterations replay the cached prefix before resuming the source from
+// there. A Cache is safe for concurrent use; consumers share the cursor and
+// each element is pulled from the source exactly once.
+//
+// Until the source is exhausted the cursor holds it suspended. Call Reset
+// to release it when a partially read Cache is no longer needed; otherwise
+// it is released when the Cache is garbage collected.
+type Cache[T any] struct {
+	mu    sync.Mutex
+	seq   iter.Seq[T]
+	cur   *cursor[func() (T, bool)]
+	items []T
+	done  bool
+	gen   int
+}
 
+// NewCache returns a Cache reading from seq. Nothing is pulled from seq
+// until the cache is first iterated.
+func NewCache[T any](seq iter.Seq[T]) *Cache[T] {
+	c := &Cache[T]{seq: seq, cur: new(cursor[func() (T, bool)])}
+	runtime.AddCleanup(c, (*cursor[func() (T, bool)]).release, c.cur)
+	return c
+}
+
+// All returns a sequence of the source's elements, served from the cache
+// where possible. An iteration in progress when Reset is called ends at
+// its next element.
+func (c *Cache[T]) All() iter.Seq[T] {
 	return func(yield func(T) bool) {
+		c.mu.Lock()
+		gen := c.gen
+		c.mu.Unlock()
+
 		for i := 0; ; i++ {
-			if i < len(cache) {
-				if !yield(cache[i]) {
-					return
-				}
-				continue
-			}
-			if done {
+			item, ok := c.at(gen, i)
+			if !ok || !yield(item) {
 				return
 			}
-			for item := range seq {
-				cache = append(cache, item)
-				if !yield(item) {
-					return
-				}
-			}
-			done = true
-			return
 		}
 	}
 }
 
-// Reusable2 caches each key/value pair pulled from seq2 so that the result
-// can be iterated multiple times. It shares the same memory trade-offs as
-// Reusable.
-func Reusable2[K, V any](seq2 iter.Seq2[K, V]) iter.Seq2[K, V] {
-	var cacheKeys []K
-	var cacheValues []V
-	var done bool
+// at returns the ith element, pulling it from the source if it has not
+// been cached yet. It reports false at the end of the source or when the
+// cache was reset after generation gen began.
+func (c *Cache[T]) at(gen, i int) (item T, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return item, false
+	}
+	if i < len(c.items) {
+		return c.items[i], true
+	}
+	if c.done {
+		return item, false
+	}
+	if c.cur.next == nil {
+		c.cur.next, c.cur.stop = iter.Pull(c.seq)
+	}
+	item, ok = c.cur.next()
+	if !ok {
+		c.cur.release()
+		c.done = true
+		return item, false
+	}
+	c.items = append(c.items, item)
+	return item, true
+}
+
+// Len returns the number of elements cached so far.
+func (c *Cache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Reset discards the cached elements and releases the cursor, so that the
+// next iteration reads the source again from the start.
+func (c *Cache[T]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cur.release()
+	c.items = nil
+	c.done = false
+	c.gen++
+}
 
+// Cache2 is the keyed companion to Cache, memoizing the key/value pairs of
+// an iter.Seq2 with the same guarantees.
+type Cache2[K, V any] struct {
+	mu     sync.Mutex
+	seq2   iter.Seq2[K, V]
+	cur    *cursor[func() (K, V, bool)]
+	keys   []K
+	values []V
+	done   bool
+	gen    int
+}
+
+// NewCache2 returns a Cache2 reading from seq2. Nothing is pulled from
+// seq2 until the cache is first iterated.
+func NewCache2[K, V any](seq2 iter.Seq2[K, V]) *Cache2[K, V] {
+	c := &Cache2[K, V]{seq2: seq2, cur: new(cursor[func() (K, V, bool)])}
+	runtime.AddCleanup(c, (*cursor[func() (K, V, bool)]).release, c.cur)
+	return c
+}
+
+// All returns a sequence of the source's pairs, served from the cache
+// where possible. An iteration in progress when Reset is called ends at
+// its next pair.
+func (c *Cache2[K, V]) All() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
+		c.mu.Lock()
+		gen := c.gen
+		c.mu.Unlock()
+
 		for i := 0; ; i++ {
-			if i < len(cacheKeys) {
-				if !yield(cacheKeys[i], cacheValues[i]) {
-					return
-				}
-				continue
-			}
-			if done {
+			k, v, ok := c.at(gen, i)
+			if !ok || !yield(k, v) {
 				return
 			}
-			for k, v := range seq2 {
-				cacheKeys = append(cacheKeys, k)
-				cacheValues = append(cacheValues, v)
-				if !yield(k, v) {
-					return
-				}
-			}
-			done = true
-			return
 		}
 	}
 }
+
+func (c *Cache2[K, V]) at(gen, i int) (k K, v V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return k, v, false
+	}
+	if i < len(c.keys) {
+		return c.keys[i], c.values[i], true
+	}
+	if c.done {
+		return k, v, false
+	}
+	if c.cur.next == nil {
+		c.cur.next, c.cur.stop = iter.Pull2(c.seq2)
+	}
+	k, v, ok = c.cur.next()
+	if !ok {
+		c.cur.release()
+		c.done = true
+		return k, v, false
+	}
+	c.keys = append(c.keys, k)
+	c.values = append(c.values, v)
+	return k, v, true
+}
+
+// Len returns the number of pairs cached so far.
+func (c *Cache2[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.keys)
+}
+
+// Reset discards the cached pairs and releases the cursor, so that the
+// next iteration reads the source again from the start.
+func (c *Cache2[K, V]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cur.release()
+	c.keys, c.values = nil, nil
+	c.done = false
+	c.gen++
+}
+
+// cursor holds an iter.Pull or iter.Pull2 cursor. It lives apart from the
+// cache reading through it so that a cleanup attached to the cache can
+// release it once the cache is unreachable.
+type cursor[F any] struct {
+	next F
+	stop func()
+}
+
+func (c *cursor[F]) release() {
+	if c.stop != nil {
+		c.stop()
+	}
+	var zero F
+	c.next, c.stop = zero, nil
+}
@@ -2,11 +2,16 @@ package iters_test
 
 import (
 	"fmt"
+	"iter"
 	"maps"
+	"runtime"
 	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
 )
 
 func ExampleReusable_twice() {
@@ -32,6 +37,19 @@ func ExampleReusable2_pairs() {
 	// 1 2
 }
 
+func ExampleCache() {
+	cache := iters.NewCache(slices.Values([]string{"a", "b", "c"}))
+
+	for s := range cache.All() {
+		fmt.Println(s)
+		break
+	}
+	fmt.Println(cache.Len(), slices.Collect(cache.All()))
+	// Output:
+	// a
+	// 1 [a b c]
+}
+
 type reusableTableTest[T comparable] struct {
 	name     string
 	input    []T
@@ -101,3 +119,178 @@ func TestReusable2(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestReusableResumesAfterEarlyStop(t *testing.T) {
+	src, c := iterstest.Counting(slices.Values([]int{1, 2, 3, 4}))
+	reusable := iters.Reusable(src)
+
+	for n := range reusable {
+		if n == 2 {
+			break
+		}
+	}
+	if got := slices.Collect(reusable); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("Reusable: expected [1 2 3 4] after an early stop, got %v", got)
+	}
+	if got := slices.Collect(reusable); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("Reusable: expected [1 2 3 4] on the third pass, got %v", got)
+	}
+	if c.Pulled() != 4 || c.Iterations() != 1 {
+		t.Fatalf("Reusable: expected one pass pulling 4 elements, got %d iterations pulling %d", c.Iterations(), c.Pulled())
+	}
+
+	iterstest.CheckSeq(t, reusable)
+}
+
+func TestReusable2ResumesAfterEarlyStop(t *testing.T) {
+	src, c := iterstest.Counting2(slices.All([]string{"a", "b", "c"}))
+	reusable := iters.Reusable2(src)
+
+	for range reusable {
+		break
+	}
+	var keys []int
+	for k := range reusable {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []int{0, 1, 2}) {
+		t.Fatalf("Reusable2: expected keys [0 1 2], got %v", keys)
+	}
+	if c.Pulled() != 3 || c.Iterations() != 1 {
+		t.Fatalf("Reusable2: expected one pass pulling 3 pairs, got %d iterations pulling %d", c.Iterations(), c.Pulled())
+	}
+}
+
+// chanSeq returns a single-use sequence of 0 through n-1: ranging over it
+// again continues where the previous iteration stopped.
+func chanSeq(n int) iter.Seq[int] {
+	ch := make(chan int, n)
+	for i := range n {
+		ch <- i
+	}
+	close(ch)
+	return func(yield func(int) bool) {
+		for i := range ch {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestReusableSingleUseSource(t *testing.T) {
+	reusable := iters.Reusable(chanSeq(5))
+	for n := range reusable {
+		if n == 1 {
+			break
+		}
+	}
+	if got := slices.Collect(reusable); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Fatalf("Reusable: expected [0 1 2 3 4] after an early stop, got %v", got)
+	}
+
+	type row struct {
+		Name string `csv:"name"`
+	}
+	records := iters.Reusable2(iters.ReadCSV[row](strings.NewReader("name\na\nb\nc\nd\n"), nil))
+	for range records {
+		break
+	}
+	var names []string
+	for r, err := range records {
+		if err != nil {
+			t.Fatalf("Reusable2: unexpected error %v", err)
+		}
+		names = append(names, r.Name)
+	}
+	if !slices.Equal(names, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("Reusable2: expected [a b c d] after an early stop, got %v", names)
+	}
+}
+
+func TestReusableReleasedWhenUnreachable(t *testing.T) {
+	iterstest.VerifyNoLeaks(t)
+
+	for range 10 {
+		for range iters.Reusable(iterstest.Naturals()) {
+			break
+		}
+		for range iters.Reusable2(iters.Enumerate(iterstest.Naturals())) {
+			break
+		}
+	}
+	runtime.GC()
+}
+
+func TestCacheConcurrentConsumers(t *testing.T) {
+	src, c := iterstest.Counting(iters.Limit(iterstest.Naturals(), 1000))
+	cache := iters.NewCache(src)
+
+	var wg sync.WaitGroup
+	results := make([][]int, 8)
+	for i := range results {
+		wg.Go(func() {
+			results[i] = slices.Collect(cache.All())
+		})
+	}
+	wg.Wait()
+
+	for i, got := range results {
+		if len(got) != 1000 || !slices.IsSorted(got) || got[999] != 999 {
+			t.Fatalf("Cache: consumer %d saw %d elements", i, len(got))
+		}
+	}
+	if c.Pulled() != 1000 || cache.Len() != 1000 {
+		t.Fatalf("Cache: expected 1000 pulled and cached, got %d and %d", c.Pulled(), cache.Len())
+	}
+}
+
+func TestCacheReset(t *testing.T) {
+	iterstest.VerifyNoLeaks(t)
+
+	src, c := iterstest.Counting(iterstest.Naturals())
+	cache := iters.NewCache(src)
+
+	for n := range cache.All() {
+		if n == 4 {
+			break
+		}
+	}
+	if cache.Len() != 5 {
+		t.Fatalf("Cache: expected 5 cached, got %d", cache.Len())
+	}
+
+	seen := 0
+	for range cache.All() {
+		seen++
+		if seen == 2 {
+			cache.Reset()
+		}
+	}
+	if seen != 2 {
+		t.Fatalf("Cache: expected Reset to end the iteration after 2 elements, got %d", seen)
+	}
+	if cache.Len() != 0 || c.Iterations() != 1 || c.Stops() != 1 {
+		t.Fatalf("Cache: expected an empty cache and a released cursor, got len %d, %d iterations, %d stops", cache.Len(), c.Iterations(), c.Stops())
+	}
+
+	if first, _ := iters.First(cache.All()); first != 0 || c.Iterations() != 2 {
+		t.Fatalf("Cache: expected the source to restart, got %d after %d iterations", first, c.Iterations())
+	}
+	cache.Reset()
+}
+
+func TestCache2Reset(t *testing.T) {
+	cache := iters.NewCache2(maps.All(map[string]int{"a": 1, "b": 2}))
+
+	if got := maps.Collect(cache.All()); len(got) != 2 || cache.Len() != 2 {
+		t.Fatalf("Cache2: expected 2 pairs, got %v", got)
+	}
+	cache.Reset()
+	if cache.Len() != 0 {
+		t.Fatalf("Cache2: expected an empty cache after Reset, got %d", cache.Len())
+	}
+	if got := maps.Collect(cache.All()); got["a"] != 1 || got["b"] != 2 {
+		t.Fatalf("Cache2: expected the pairs again after Reset, got %v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package iters
+
+import "iter"
+
+// Scan returns a sequence of the running accumulations of seq: for each
+// element it applies fn to the accumulator and the element, and yields the
+// new accumulator. The initial value is not yielded, so Scan yields one
+// value per element and its last value is what Reduce would return.
+func Scan[T, R any](seq iter.Seq[T], fn func(R, T) R, initial R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		acc := initial
+		for item := range seq {
+			acc = fn(acc, item)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// ScanWhile is like Scan, but fn also reports whether to continue. When fn
+// reports false, the accumulator it returned is yielded and the sequence
+// ends without reading further from seq.
+func ScanWhile[T, R any](seq iter.Seq[T], fn func(R, T) (R, bool), initial R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		acc := initial
+		for item := range seq {
+			var more bool
+			acc, more = fn(acc, item)
+			if !yield(acc) || !more {
+				return
+			}
+		}
+	}
+}
+
+// ReduceWhile is like Reduce, but fn also reports whether to continue.
+// When fn reports false, the accumulator it returned is the result and
+// seq is not read any further.
+func ReduceWhile[T, R any](seq iter.Seq[T], fn func(R, T) (R, bool), initial R) R {
+	for item := range seq {
+		var more bool
+		initial, more = fn(initial, item)
+		if !more {
+			break
+		}
+	}
+	return initial
+}
+
+// Scan2 is the keyed companion to Scan. It yields each key of seq2 together
+// with the accumulator after folding in that key/value pair.
+func Scan2[K, V, R any](seq2 iter.Seq2[K, V], fn func(R, K, V) R, initial R) iter.Seq2[K, R] {
+	return func(yield func(K, R) bool) {
+		acc := initial
+		for k, v := range seq2 {
+			acc = fn(acc, k, v)
+			if !yield(k, acc) {
+				return
+			}
+		}
+	}
+}
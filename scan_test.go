@@ -0,0 +1,106 @@
+package iters_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleScan_runningTotal() {
+	sum := func(acc, n int) int { return acc + n }
+	fmt.Println(slices.Collect(iters.Scan(slices.Values([]int{3, 1, 4, 1, 5}), sum, 0)))
+	// Output:
+	// [3 4 8 9 14]
+}
+
+func ExampleScan_cumulativeMax() {
+	highest := func(acc, n int) int { return max(acc, n) }
+	fmt.Println(slices.Collect(iters.Scan(slices.Values([]int{3, 1, 4, 1, 5}), highest, 0)))
+	// Output:
+	// [3 3 4 4 5]
+}
+
+func ExampleReduceWhile() {
+	// Add up numbers until the total would exceed 10.
+	total := iters.ReduceWhile(slices.Values([]int{4, 3, 2, 5, 1}), func(acc, n int) (int, bool) {
+		if acc+n > 10 {
+			return acc, false
+		}
+		return acc + n, true
+	}, 0)
+	fmt.Println(total)
+	// Output:
+	// 9
+}
+
+func ExampleScan2() {
+	latencies := slices.All([]int{120, 80, 200})
+	for i, total := range iters.Scan2(latencies, func(acc, _ int, ms int) int { return acc + ms }, 0) {
+		fmt.Println(i, total)
+	}
+	// Output:
+	// 0 120
+	// 1 200
+	// 2 400
+}
+
+type scanTableTest[T, R comparable] struct {
+	name     string
+	input    []T
+	fn       func(R, T) R
+	initial  R
+	expected []R
+}
+
+func (test scanTableTest[T, R]) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		got := slices.Collect(iters.Scan(slices.Values(test.input), test.fn, test.initial))
+		if !slices.Equal(got, test.expected) {
+			t.Fatalf("Scan: expected %v, got %v", test.expected, got)
+		}
+		iterstest.CheckSeq(t, iters.Scan(slices.Values(test.input), test.fn, test.initial))
+	})
+}
+
+func TestScan(t *testing.T) {
+	tests := []runnableTest{
+		scanTableTest[int, int]{name: "sum", input: []int{1, 2, 3}, fn: func(a, b int) int { return a + b }, initial: 10, expected: []int{11, 13, 16}},
+		scanTableTest[string, int]{name: "lengths", input: []string{"a", "bc"}, fn: func(a int, s string) int { return a + len(s) }, expected: []int{1, 3}},
+		scanTableTest[int, int]{name: "empty", input: nil, fn: func(a, b int) int { return a + b }, initial: 5, expected: nil},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestScanEndsWithReduce(t *testing.T) {
+	sum := func(acc, n int) int { return acc + n }
+	gen.ForAll(t, gen.Slice(gen.Int(-100, 100), 20), func(s []int) bool {
+		scanned := slices.Collect(iters.Scan(slices.Values(s), sum, 7))
+		if len(scanned) != len(s) {
+			return false
+		}
+		return len(s) == 0 || scanned[len(s)-1] == iters.Reduce(slices.Values(s), sum, 7)
+	})
+}
+
+func TestScanWhileStopsReading(t *testing.T) {
+	src, c := iterstest.Counting(iterstest.Naturals())
+	untilTen := func(acc, n int) (int, bool) { return acc + n, acc+n < 10 }
+
+	if got := slices.Collect(iters.ScanWhile(src, untilTen, 0)); !slices.Equal(got, []int{0, 1, 3, 6, 10}) {
+		t.Fatalf("ScanWhile: expected [0 1 3 6 10], got %v", got)
+	}
+	if c.Pulled() != 5 {
+		t.Fatalf("ScanWhile: expected 5 pulled, got %d", c.Pulled())
+	}
+
+	c.Reset()
+	if got := iters.ReduceWhile(src, untilTen, 0); got != 10 || c.Pulled() != 5 {
+		t.Fatalf("ReduceWhile: expected 10 after pulling 5, got %d after %d", got, c.Pulled())
+	}
+}
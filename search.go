@@ -0,0 +1,98 @@
+package iters
+
+import (
+	"iter"
+	"slices"
+)
+
+// IndexSeq returns the position in seq where the first occurrence of the
+// contiguous subsequence sub starts, or -1 if sub does not occur. An empty
+// sub occurs at position 0.
+//
+// sub is collected up front, so it must be finite. seq is read once, using
+// the Knuth-Morris-Pratt algorithm, so no element is read twice and memory
+// is proportional to the length of sub; reading stops at the first match.
+func IndexSeq[V comparable](seq iter.Seq[V], sub iter.Seq[V]) int {
+	pattern := slices.Collect(sub)
+	if len(pattern) == 0 {
+		return 0
+	}
+
+	// fallback[i] is the length of the longest proper prefix of
+	// pattern[:i+1] that is also a suffix of it.
+	fallback := make([]int, len(pattern))
+	for i, k := 1, 0; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = fallback[k-1]
+		}
+		if pattern[i] == pattern[k] {
+			k++
+		}
+		fallback[i] = k
+	}
+
+	i, matched := 0, 0
+	for item := range seq {
+		for matched > 0 && item != pattern[matched] {
+			matched = fallback[matched-1]
+		}
+		if item == pattern[matched] {
+			matched++
+		}
+		if matched == len(pattern) {
+			return i - len(pattern) + 1
+		}
+		i++
+	}
+	return -1
+}
+
+// ContainsSeq reports whether the contiguous subsequence sub occurs in
+// seq, as found by IndexSeq.
+func ContainsSeq[V comparable](seq iter.Seq[V], sub iter.Seq[V]) bool {
+	return IndexSeq(seq, sub) >= 0
+}
+
+// HasPrefix reports whether seq begins with the elements of prefix. The
+// two are read in lockstep, so both may be infinite as long as they differ
+// somewhere; reading stops at the first difference or at the end of
+// prefix.
+func HasPrefix[V comparable](seq iter.Seq[V], prefix iter.Seq[V]) bool {
+	next, stop := iter.Pull(seq)
+	defer stop()
+
+	for want := range prefix {
+		got, ok := next()
+		if !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// HasSuffix reports whether seq ends with the elements of suffix. suffix
+// is collected up front and seq is read to the end, so both must be
+// finite; only the last len(suffix) elements of seq are kept in memory.
+func HasSuffix[V comparable](seq iter.Seq[V], suffix iter.Seq[V]) bool {
+	want := slices.Collect(suffix)
+	if len(want) == 0 {
+		return true
+	}
+
+	// Keep the most recent elements of seq in a ring buffer.
+	last := make([]V, len(want))
+	n := 0
+	for item := range seq {
+		last[n%len(last)] = item
+		n++
+	}
+	if n < len(want) {
+		return false
+	}
+	for i, v := range want {
+		if last[(n+i)%len(last)] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package iters_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleIndexSeq() {
+	haystack := slices.Values(strings.Split("abcabcabd", ""))
+	needle := slices.Values(strings.Split("abcabd", ""))
+	fmt.Println(iters.IndexSeq(haystack, needle))
+	// Output:
+	// 3
+}
+
+func ExampleHasPrefix() {
+	fmt.Println(iters.HasPrefix(iterstest.Naturals(), slices.Values([]int{0, 1, 2})))
+	fmt.Println(iters.HasSuffix(slices.Values([]int{1, 2, 3}), slices.Values([]int{2, 3})))
+	// Output:
+	// true
+	// true
+}
+
+// searchInput generates a haystack and needle over a small alphabet, so
+// that partial matches, which exercise the KMP fallback, are common.
+var searchInput = func(r *rand.Rand) [2]string {
+	return [2]string{gen.String("ab", 16)(r), gen.String("ab", 4)(r)}
+}
+
+func letters(s string) []string {
+	return strings.Split(s, "")
+}
+
+func TestIndexSeqMatchesStrings(t *testing.T) {
+	gen.ForAll(t, searchInput, func(in [2]string) bool {
+		haystack, needle := in[0], in[1]
+		got := iters.IndexSeq(slices.Values(letters(haystack)), slices.Values(letters(needle)))
+		return got == strings.Index(haystack, needle) &&
+			iters.ContainsSeq(slices.Values(letters(haystack)), slices.Values(letters(needle))) == strings.Contains(haystack, needle)
+	})
+}
+
+func TestPrefixSuffixMatchStrings(t *testing.T) {
+	gen.ForAll(t, searchInput, func(in [2]string) bool {
+		s, affix := in[0], in[1]
+		return iters.HasPrefix(slices.Values(letters(s)), slices.Values(letters(affix))) == strings.HasPrefix(s, affix) &&
+			iters.HasSuffix(slices.Values(letters(s)), slices.Values(letters(affix))) == strings.HasSuffix(s, affix)
+	})
+}
+
+func TestIndexSeqReadsOnce(t *testing.T) {
+	src, c := iterstest.Counting(iterstest.Naturals())
+
+	if got := iters.IndexSeq(src, slices.Values([]int{10, 11, 12})); got != 10 {
+		t.Fatalf("IndexSeq: expected 10, got %d", got)
+	}
+	if c.Iterations() != 1 || c.Pulled() != 13 {
+		t.Fatalf("IndexSeq: expected 1 iteration pulling 13, got %d pulling %d", c.Iterations(), c.Pulled())
+	}
+}
+
+func TestHasPrefixStopsEarly(t *testing.T) {
+	src, c := iterstest.Counting(iterstest.Naturals())
+
+	if iters.HasPrefix(src, slices.Values([]int{0, 1, 5, 3})) {
+		t.Fatalf("HasPrefix: expected false")
+	}
+	if c.Pulled() != 3 {
+		t.Fatalf("HasPrefix: expected 3 pulled, got %d", c.Pulled())
+	}
+}
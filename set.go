@@ -0,0 +1,138 @@
+package iters
+
+import "iter"
+
+// Union returns a sequence of the distinct elements of a followed by the
+// distinct elements of b that are not in a, in first occurrence order.
+func Union[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return UnionFunc(a, b, identity[T])
+}
+
+// UnionFunc behaves like Union but compares elements by the key returned
+// from key, yielding the first element seen for each key.
+func UnionFunc[T any, K comparable](a, b iter.Seq[T], key func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for _, seq := range []iter.Seq[T]{a, b} {
+			for item := range seq {
+				k := key(item)
+				if _, ok := seen[k]; ok {
+					continue
+				}
+				seen[k] = struct{}{}
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Intersect returns a sequence of the distinct elements of a that are also
+// in b, in the order they appear in a. b is read in full into a set when
+// iteration starts, so pass the smaller input as b.
+func Intersect[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return IntersectFunc(a, b, identity[T])
+}
+
+// IntersectFunc behaves like Intersect but compares elements by the key
+// returned from key.
+func IntersectFunc[T any, K comparable](a, b iter.Seq[T], key func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		keep := keySet(b, key)
+		for item := range a {
+			k := key(item)
+			if _, ok := keep[k]; !ok {
+				continue
+			}
+			delete(keep, k)
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Difference returns a sequence of the distinct elements of a that are not
+// in b, in the order they appear in a. b is read in full into a set when
+// iteration starts.
+func Difference[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return DifferenceFunc(a, b, identity[T])
+}
+
+// DifferenceFunc behaves like Difference but compares elements by the key
+// returned from key.
+func DifferenceFunc[T any, K comparable](a, b iter.Seq[T], key func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skip := keySet(b, key)
+		for item := range a {
+			k := key(item)
+			if _, ok := skip[k]; ok {
+				continue
+			}
+			skip[k] = struct{}{}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SymmetricDifference returns a sequence of the distinct elements that are
+// in exactly one of a and b: first those from a, then those from b, each in
+// first occurrence order. Both inputs are read in full when iteration
+// starts.
+func SymmetricDifference[T comparable](a, b iter.Seq[T]) iter.Seq[T] {
+	return SymmetricDifferenceFunc(a, b, identity[T])
+}
+
+// SymmetricDifferenceFunc behaves like SymmetricDifference but compares
+// elements by the key returned from key.
+func SymmetricDifferenceFunc[T any, K comparable](a, b iter.Seq[T], key func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		aItems, aKeys := distinctByKey(a, key)
+		bItems, bKeys := distinctByKey(b, key)
+		for _, side := range []struct {
+			items []T
+			other map[K]struct{}
+		}{{aItems, bKeys}, {bItems, aKeys}} {
+			for _, item := range side.items {
+				if _, ok := side.other[key(item)]; ok {
+					continue
+				}
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func identity[T any](v T) T {
+	return v
+}
+
+// keySet returns the set of keys of the elements of seq.
+func keySet[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K]struct{} {
+	set := make(map[K]struct{})
+	for item := range seq {
+		set[key(item)] = struct{}{}
+	}
+	return set
+}
+
+// distinctByKey returns the first element of seq for each key, in order,
+// along with the set of keys.
+func distinctByKey[T any, K comparable](seq iter.Seq[T], key func(T) K) ([]T, map[K]struct{}) {
+	var items []T
+	set := make(map[K]struct{})
+	for item := range seq {
+		k := key(item)
+		if _, ok := set[k]; ok {
+			continue
+		}
+		set[k] = struct{}{}
+		items = append(items, item)
+	}
+	return items, set
+}
@@ -0,0 +1,98 @@
+package iters_test
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func ExampleUnion() {
+	a := slices.Values([]string{"go", "rust", "go"})
+	b := slices.Values([]string{"zig", "rust"})
+
+	fmt.Println(slices.Collect(iters.Union(a, b)))
+	fmt.Println(slices.Collect(iters.Intersect(a, b)))
+	fmt.Println(slices.Collect(iters.Difference(a, b)))
+	fmt.Println(slices.Collect(iters.SymmetricDifference(a, b)))
+	// Output:
+	// [go rust zig]
+	// [rust]
+	// [go]
+	// [go zig]
+}
+
+type setTableTest[T comparable] struct {
+	name     string
+	op       func(a, b iter.Seq[T]) iter.Seq[T]
+	a, b     []T
+	expected []T
+}
+
+func (test setTableTest[T]) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		got := slices.Collect(test.op(slices.Values(test.a), slices.Values(test.b)))
+		if !slices.Equal(got, test.expected) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+		iterstest.CheckSeq(t, test.op(slices.Values(test.a), slices.Values(test.b)))
+	})
+}
+
+func TestSetOperations(t *testing.T) {
+	a, b := []int{3, 1, 2, 3, 5}, []int{5, 4, 4, 1}
+	tests := []runnableTest{
+		setTableTest[int]{name: "Union", op: iters.Union[int], a: a, b: b, expected: []int{3, 1, 2, 5, 4}},
+		setTableTest[int]{name: "Intersect", op: iters.Intersect[int], a: a, b: b, expected: []int{1, 5}},
+		setTableTest[int]{name: "Difference", op: iters.Difference[int], a: a, b: b, expected: []int{3, 2}},
+		setTableTest[int]{name: "SymmetricDifference", op: iters.SymmetricDifference[int], a: a, b: b, expected: []int{3, 2, 4}},
+		setTableTest[int]{name: "Union empty", op: iters.Union[int], a: nil, b: nil, expected: nil},
+		setTableTest[int]{name: "Intersect empty", op: iters.Intersect[int], a: a, b: nil, expected: nil},
+		setTableTest[int]{name: "Difference empty", op: iters.Difference[int], a: a, b: nil, expected: []int{3, 1, 2, 5}},
+	}
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestSetOperationsFunc(t *testing.T) {
+	a := slices.Values([]string{"Go", "Rust", "GO"})
+	b := slices.Values([]string{"rust", "Zig"})
+
+	tests := []struct {
+		name     string
+		got      iter.Seq[string]
+		expected []string
+	}{
+		{"UnionFunc", iters.UnionFunc(a, b, strings.ToLower), []string{"Go", "Rust", "Zig"}},
+		{"IntersectFunc", iters.IntersectFunc(a, b, strings.ToLower), []string{"Rust"}},
+		{"DifferenceFunc", iters.DifferenceFunc(a, b, strings.ToLower), []string{"Go"}},
+		{"SymmetricDifferenceFunc", iters.SymmetricDifferenceFunc(a, b, strings.ToLower), []string{"Go", "Zig"}},
+	}
+	for _, test := range tests {
+		if got := slices.Collect(test.got); !slices.Equal(got, test.expected) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestSetOperationsAreLazyInA(t *testing.T) {
+	src, c := iterstest.Counting(iterstest.Naturals())
+	b := slices.Values([]int{2, 4, 6})
+
+	if first, _ := iters.First(iters.Intersect(src, b)); first != 2 || c.Pulled() != 3 {
+		t.Fatalf("Intersect: expected 2 after pulling 3, got %d after %d", first, c.Pulled())
+	}
+	c.Reset()
+	if first, _ := iters.First(iters.Difference(src, b)); first != 0 || c.Pulled() != 1 {
+		t.Fatalf("Difference: expected 0 after pulling 1, got %d after %d", first, c.Pulled())
+	}
+	c.Reset()
+	if got := slices.Collect(iters.Limit(iters.Union(src, b), 3)); !slices.Equal(got, []int{0, 1, 2}) || c.Pulled() != 3 {
+		t.Fatalf("Union: expected [0 1 2] after pulling 3, got %v after %d", got, c.Pulled())
+	}
+}
@@ -26,3 +26,28 @@ func SortFunc[T any](seq iter.Seq[T], cmp func(a T, b T) int) iter.Seq[T] {
 
 	return slices.Values(items)
 }
+
+// Sort2 returns a sequence of the pairs of seq2 sorted by key in ascending
+// order. Pairs with equal keys keep their relative order. The input is
+// collected when iteration starts.
+func Sort2[K cmp.Ordered, V any](seq2 iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return SortFunc2(seq2, func(k1 K, _ V, k2 K, _ V) int { return cmp.Compare(k1, k2) })
+}
+
+// SortFunc2 behaves like Sort2 but orders the pairs with cmp. The sort is
+// stable.
+func SortFunc2[K, V any](seq2 iter.Seq2[K, V], cmp func(k1 K, v1 V, k2 K, v2 V) int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		pairs := slices.Collect(Pairs(seq2))
+
+		slices.SortStableFunc(pairs, func(a, b Pair[K, V]) int {
+			return cmp(a.Key, a.Value, b.Key, b.Value)
+		})
+
+		for _, p := range pairs {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}
+}
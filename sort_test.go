@@ -3,6 +3,7 @@ package iters_test
 import (
 	"fmt"
 	"slices"
+	"strings"
 	"testing"
 
 	"cmp"
@@ -84,3 +85,18 @@ func TestSortFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestSort2(t *testing.T) {
+	input := iters.Unpairs(slices.Values([]iters.Pair[int, string]{{3, "c"}, {1, "a"}, {3, "b"}, {2, "x"}}))
+
+	want := []iters.Pair[int, string]{{1, "a"}, {2, "x"}, {3, "c"}, {3, "b"}}
+	if got := slices.Collect(iters.Pairs(iters.Sort2(input))); !slices.Equal(got, want) {
+		t.Fatalf("Sort2: expected %v, got %v", want, got)
+	}
+
+	byValue := func(_ int, a string, _ int, b string) int { return strings.Compare(a, b) }
+	want = []iters.Pair[int, string]{{1, "a"}, {3, "b"}, {3, "c"}, {2, "x"}}
+	if got := slices.Collect(iters.Pairs(iters.SortFunc2(input, byValue))); !slices.Equal(got, want) {
+		t.Fatalf("SortFunc2: expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,206 @@
+package iters
+
+import (
+	"cmp"
+	"iter"
+)
+
+// The sorted set operations merge two sequences that are sorted in
+// ascending order, reading each input once and holding only the current
+// element of each in memory. Inputs that are not sorted produce
+// unspecified results.
+//
+// The Sorted functions treat their inputs as sets: equal elements within
+// an input are collapsed, and each distinct element is yielded at most
+// once. The SortedMultiset functions treat their inputs as multisets and
+// respect counts: an element appearing m times in a and n times in b
+// appears max(m, n) times in the union, min(m, n) times in the
+// intersection, m-n times in the difference when m > n, and |m-n| times in
+// the symmetric difference. All outputs are sorted.
+
+// setOp selects which elements of a sorted merge are yielded: those only
+// in a, those only in b, and those in both.
+type setOp struct {
+	onlyA, onlyB, both bool
+}
+
+var (
+	unionOp      = setOp{onlyA: true, onlyB: true, both: true}
+	intersectOp  = setOp{both: true}
+	differenceOp = setOp{onlyA: true}
+	symmetricOp  = setOp{onlyA: true, onlyB: true}
+)
+
+// SortedUnion returns the sorted union of the sorted sets a and b.
+func SortedUnion[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], unionOp, false)
+}
+
+// SortedUnionFunc is like SortedUnion for inputs sorted by cmp.
+func SortedUnionFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, unionOp, false)
+}
+
+// SortedIntersect returns the sorted intersection of the sorted sets a and
+// b.
+func SortedIntersect[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], intersectOp, false)
+}
+
+// SortedIntersectFunc is like SortedIntersect for inputs sorted by cmp.
+func SortedIntersectFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, intersectOp, false)
+}
+
+// SortedDifference returns the elements of the sorted set a that are not in
+// the sorted set b, in order.
+func SortedDifference[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], differenceOp, false)
+}
+
+// SortedDifferenceFunc is like SortedDifference for inputs sorted by cmp.
+func SortedDifferenceFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, differenceOp, false)
+}
+
+// SortedSymmetricDifference returns the elements that are in exactly one of
+// the sorted sets a and b, in order.
+func SortedSymmetricDifference[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], symmetricOp, false)
+}
+
+// SortedSymmetricDifferenceFunc is like SortedSymmetricDifference for
+// inputs sorted by cmp.
+func SortedSymmetricDifferenceFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, symmetricOp, false)
+}
+
+// SortedMultisetUnion returns the sorted multiset union of a and b.
+func SortedMultisetUnion[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], unionOp, true)
+}
+
+// SortedMultisetUnionFunc is like SortedMultisetUnion for inputs sorted by
+// cmp.
+func SortedMultisetUnionFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, unionOp, true)
+}
+
+// SortedMultisetIntersect returns the sorted multiset intersection of a and
+// b.
+func SortedMultisetIntersect[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], intersectOp, true)
+}
+
+// SortedMultisetIntersectFunc is like SortedMultisetIntersect for inputs
+// sorted by cmp.
+func SortedMultisetIntersectFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, intersectOp, true)
+}
+
+// SortedMultisetDifference returns the sorted multiset difference of a and
+// b.
+func SortedMultisetDifference[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], differenceOp, true)
+}
+
+// SortedMultisetDifferenceFunc is like SortedMultisetDifference for inputs
+// sorted by cmp.
+func SortedMultisetDifferenceFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, differenceOp, true)
+}
+
+// SortedMultisetSymmetricDifference returns the sorted multiset symmetric
+// difference of a and b.
+func SortedMultisetSymmetricDifference[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return mergeSorted(a, b, cmp.Compare[T], symmetricOp, true)
+}
+
+// SortedMultisetSymmetricDifferenceFunc is like
+// SortedMultisetSymmetricDifference for inputs sorted by cmp.
+func SortedMultisetSymmetricDifferenceFunc[T any](a, b iter.Seq[T], cmp func(x, y T) int) iter.Seq[T] {
+	return mergeSorted(a, b, cmp, symmetricOp, true)
+}
+
+// mergeSorted walks a and b in step. Equal elements from the two inputs
+// are paired off one to one; in set mode each input first has its runs of
+// equal elements collapsed.
+func mergeSorted[T any](a, b iter.Seq[T], cmp func(x, y T) int, op setOp, multiset bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+
+		if !multiset {
+			nextA = distinctSorted(nextA, cmp)
+			nextB = distinctSorted(nextB, cmp)
+		}
+
+		x, okA := nextA()
+		y, okB := nextB()
+		for okA || okB {
+			// Stop reading once the remaining input cannot contribute.
+			if !okB && !op.onlyA || !okA && !op.onlyB {
+				return
+			}
+			switch c := compareSides(x, okA, y, okB, cmp); {
+			case c < 0:
+				if op.onlyA && !yield(x) {
+					return
+				}
+				x, okA = nextA()
+			case c > 0:
+				if op.onlyB && !yield(y) {
+					return
+				}
+				y, okB = nextB()
+			default:
+				if op.both && !yield(x) {
+					return
+				}
+				x, okA = nextA()
+				y, okB = nextB()
+			}
+		}
+	}
+}
+
+// compareSides orders the current elements of the two inputs, treating an
+// exhausted input as greater than any element.
+func compareSides[T any](x T, okA bool, y T, okB bool, cmp func(x, y T) int) int {
+	switch {
+	case !okB:
+		return -1
+	case !okA:
+		return 1
+	default:
+		return cmp(x, y)
+	}
+}
+
+// distinctSorted wraps next so that runs of equal elements are returned
+// once.
+func distinctSorted[T any](next func() (T, bool), cmp func(x, y T) int) func() (T, bool) {
+	var (
+		pending T
+		has     bool
+		started bool
+	)
+	return func() (T, bool) {
+		if !started {
+			started = true
+			pending, has = next()
+		}
+		if !has {
+			return pending, false
+		}
+		current := pending
+		for {
+			pending, has = next()
+			if !has || cmp(pending, current) != 0 {
+				return current, true
+			}
+		}
+	}
+}
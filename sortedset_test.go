@@ -0,0 +1,142 @@
+package iters_test
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleSortedUnion() {
+	a := slices.Values([]int{1, 3, 3, 5})
+	b := slices.Values([]int{2, 3, 5, 5})
+
+	fmt.Println(slices.Collect(iters.SortedUnion(a, b)))
+	fmt.Println(slices.Collect(iters.SortedMultisetUnion(a, b)))
+	// Output:
+	// [1 2 3 5]
+	// [1 2 3 3 5 5]
+}
+
+type sortedPair struct {
+	a, b []int
+}
+
+var sortedPairs = func(r *rand.Rand) sortedPair {
+	s := gen.Slice(gen.Int(0, 8), 12)
+	a, b := s(r), s(r)
+	slices.Sort(a)
+	slices.Sort(b)
+	return sortedPair{a, b}
+}
+
+func TestSortedSetsMatchHashSets(t *testing.T) {
+	ops := []struct {
+		name   string
+		sorted func(a, b iter.Seq[int]) iter.Seq[int]
+		hash   func(a, b iter.Seq[int]) iter.Seq[int]
+	}{
+		{"Union", iters.SortedUnion[int], iters.Union[int]},
+		{"Intersect", iters.SortedIntersect[int], iters.Intersect[int]},
+		{"Difference", iters.SortedDifference[int], iters.Difference[int]},
+		{"SymmetricDifference", iters.SortedSymmetricDifference[int], iters.SymmetricDifference[int]},
+	}
+	for _, op := range ops {
+		t.Run(op.name, func(t *testing.T) {
+			gen.ForAll(t, sortedPairs, func(p sortedPair) bool {
+				a, b := slices.Values(p.a), slices.Values(p.b)
+				got := slices.Collect(op.sorted(a, b))
+				want := slices.Sorted(op.hash(a, b))
+				return slices.Equal(got, want)
+			})
+		})
+	}
+}
+
+func TestSortedMultisetCounts(t *testing.T) {
+	ops := []struct {
+		name  string
+		op    func(a, b iter.Seq[int]) iter.Seq[int]
+		count func(m, n int) int
+	}{
+		{"Union", iters.SortedMultisetUnion[int], func(m, n int) int { return max(m, n) }},
+		{"Intersect", iters.SortedMultisetIntersect[int], func(m, n int) int { return min(m, n) }},
+		{"Difference", iters.SortedMultisetDifference[int], func(m, n int) int { return max(m-n, 0) }},
+		{"SymmetricDifference", iters.SortedMultisetSymmetricDifference[int], func(m, n int) int { return max(m-n, n-m) }},
+	}
+	for _, op := range ops {
+		t.Run(op.name, func(t *testing.T) {
+			gen.ForAll(t, sortedPairs, func(p sortedPair) bool {
+				var want []int
+				for v := range 9 {
+					m, n := countOf(p.a, v), countOf(p.b, v)
+					for range op.count(m, n) {
+						want = append(want, v)
+					}
+				}
+				got := slices.Collect(op.op(slices.Values(p.a), slices.Values(p.b)))
+				return slices.Equal(got, want)
+			})
+		})
+	}
+}
+
+func countOf(s []int, v int) int {
+	n := 0
+	for _, x := range s {
+		if x == v {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSortedSetsFunc(t *testing.T) {
+	a := slices.Values([]string{"a", "B", "c"})
+	b := slices.Values([]string{"A", "b", "b", "D"})
+	byFold := func(x, y string) int { return strings.Compare(strings.ToLower(x), strings.ToLower(y)) }
+
+	tests := []struct {
+		name     string
+		got      iter.Seq[string]
+		expected []string
+	}{
+		{"SortedUnionFunc", iters.SortedUnionFunc(a, b, byFold), []string{"a", "B", "c", "D"}},
+		{"SortedIntersectFunc", iters.SortedIntersectFunc(a, b, byFold), []string{"a", "B"}},
+		{"SortedDifferenceFunc", iters.SortedDifferenceFunc(a, b, byFold), []string{"c"}},
+		{"SortedSymmetricDifferenceFunc", iters.SortedSymmetricDifferenceFunc(a, b, byFold), []string{"c", "D"}},
+		{"SortedMultisetUnionFunc", iters.SortedMultisetUnionFunc(a, b, byFold), []string{"a", "B", "b", "c", "D"}},
+		{"SortedMultisetIntersectFunc", iters.SortedMultisetIntersectFunc(a, b, byFold), []string{"a", "B"}},
+		{"SortedMultisetDifferenceFunc", iters.SortedMultisetDifferenceFunc(a, b, byFold), []string{"c"}},
+		{"SortedMultisetSymmetricDifferenceFunc", iters.SortedMultisetSymmetricDifferenceFunc(a, b, byFold), []string{"b", "c", "D"}},
+	}
+	for _, test := range tests {
+		if got := slices.Collect(test.got); !slices.Equal(got, test.expected) {
+			t.Fatalf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestSortedSetsStream(t *testing.T) {
+	evens := iters.Range(0, 1<<62, 2)
+	src, c := iterstest.Counting(iters.Range(0, 1<<62, 3))
+
+	// Both inputs are effectively infinite, so only a streaming merge can
+	// produce a prefix of the intersection.
+	got := slices.Collect(iters.Limit(iters.SortedIntersect(evens, src), 4))
+	if !slices.Equal(got, []int{0, 6, 12, 18}) {
+		t.Fatalf("SortedIntersect: expected [0 6 12 18], got %v", got)
+	}
+	if c.Pulled() > 8 {
+		t.Fatalf("SortedIntersect: expected a short read, got %d", c.Pulled())
+	}
+
+	iterstest.CheckSeq(t, iters.SortedUnion(evens, iters.Range(0, 1<<62, 3)))
+	iterstest.CheckSeq(t, iters.SortedMultisetDifference(slices.Values([]int{1, 1, 2}), slices.Values([]int{1})))
+}
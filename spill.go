@@ -0,0 +1,221 @@
+package iters
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"os"
+	"sync"
+)
+
+// Codec converts values of type T to and from bytes. It is used by
+// [ReusableSpill] to store elements outside of memory.
+type Codec[T any] interface {
+	Encode(v T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// GobCodec is a Codec using encoding/gob. Each value is encoded on its
+// own, together with its type information.
+type GobCodec[T any] struct{}
+
+// Encode returns the gob encoding of v.
+func (GobCodec[T]) Encode(v T) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+// Decode decodes a value encoded by Encode.
+func (GobCodec[T]) Decode(data []byte) (v T, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+	return v, err
+}
+
+// JSONCodec is a Codec using encoding/json.
+type JSONCodec[T any] struct{}
+
+// Encode returns the JSON encoding of v.
+func (JSONCodec[T]) Encode(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Decode decodes a value encoded by Encode.
+func (JSONCodec[T]) Decode(data []byte) (v T, err error) {
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+// Spill is a multi-pass cache like [Cache] that holds at most a fixed
+// number of elements in memory and writes the rest, encoded with a Codec,
+// to a temporary file. It is created by ReusableSpill and must be closed
+// to remove the file.
+type Spill[T any] struct {
+	mu      sync.Mutex
+	seq     iter.Seq[T]
+	codec   Codec[T]
+	next    func() (T, bool)
+	stop    func()
+	items   []T
+	budget  int
+	file    *os.File
+	offsets []int64 // start of each spilled element, then the end of the file
+	done    bool
+	closed  bool
+	err     error
+}
+
+// ReusableSpill caches the elements of seq so that they can be iterated
+// multiple times, like [Reusable], but keeps only the first memBudget
+// elements in memory. Later elements are encoded with codec and appended
+// to a temporary file, from which subsequent passes read them back. The
+// source is pulled lazily through a single cursor, and the cache is safe
+// for concurrent use.
+//
+// Encoding, decoding and file errors are yielded by the sequence returned
+// by All and end the iteration; an encoding or write error also ends the
+// cache, so later passes stop at the same point and report it again.
+func ReusableSpill[T any](seq iter.Seq[T], memBudget int, codec Codec[T]) *Spill[T] {
+	return &Spill[T]{seq: seq, codec: codec, budget: max(memBudget, 0), offsets: []int64{0}}
+}
+
+// All returns a sequence of the source's elements, read from memory, the
+// spill file or the source as needed. Iterating a closed Spill yields
+// [os.ErrClosed].
+func (s *Spill[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for i := 0; ; i++ {
+			item, ok, err := s.at(i)
+			if err != nil {
+				yield(item, err)
+				return
+			}
+			if !ok || !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Spill[T]) at(i int) (item T, ok bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return item, false, os.ErrClosed
+	}
+	if i < s.len() {
+		return s.read(i)
+	}
+	if s.err != nil {
+		return item, false, s.err
+	}
+	if s.done {
+		return item, false, nil
+	}
+	if s.next == nil {
+		s.next, s.stop = iter.Pull(s.seq)
+	}
+	item, ok = s.next()
+	if !ok {
+		s.release()
+		s.done = true
+		return item, false, nil
+	}
+	if err := s.store(item); err != nil {
+		s.release()
+		s.err = err
+		return item, false, err
+	}
+	return item, true, nil
+}
+
+// len returns the number of cached elements.
+func (s *Spill[T]) len() int {
+	return len(s.items) + len(s.offsets) - 1
+}
+
+func (s *Spill[T]) read(i int) (item T, ok bool, err error) {
+	if i < len(s.items) {
+		return s.items[i], true, nil
+	}
+	i -= len(s.items)
+	data := make([]byte, s.offsets[i+1]-s.offsets[i])
+	if _, err := s.file.ReadAt(data, s.offsets[i]); err != nil {
+		return item, false, fmt.Errorf("iters: reading spilled element: %w", err)
+	}
+	item, err = s.codec.Decode(data)
+	if err != nil {
+		return item, false, fmt.Errorf("iters: decoding spilled element: %w", err)
+	}
+	return item, true, nil
+}
+
+func (s *Spill[T]) store(item T) error {
+	if len(s.items) < s.budget {
+		s.items = append(s.items, item)
+		return nil
+	}
+	data, err := s.codec.Encode(item)
+	if err != nil {
+		return fmt.Errorf("iters: encoding element to spill: %w", err)
+	}
+	if s.file == nil {
+		s.file, err = os.CreateTemp("", "iters-spill-*")
+		if err != nil {
+			return fmt.Errorf("iters: creating spill file: %w", err)
+		}
+	}
+	end := s.offsets[len(s.offsets)-1]
+	if _, err := s.file.WriteAt(data, end); err != nil {
+		return fmt.Errorf("iters: writing spill file: %w", err)
+	}
+	s.offsets = append(s.offsets, end+int64(len(data)))
+	return nil
+}
+
+func (s *Spill[T]) release() {
+	if s.stop != nil {
+		s.stop()
+	}
+	s.next, s.stop = nil, nil
+}
+
+// Len returns the number of elements cached so far, in memory or on disk.
+func (s *Spill[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.len()
+}
+
+// Spilled returns the number of cached elements stored in the spill file.
+func (s *Spill[T]) Spilled() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.offsets) - 1
+}
+
+// Close releases the source cursor and removes the spill file. Iterations
+// that are still running end at their next element.
+func (s *Spill[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.release()
+	s.items = nil
+	if s.file == nil {
+		return nil
+	}
+	name := s.file.Name()
+	err := s.file.Close()
+	if rerr := os.Remove(name); err == nil {
+		err = rerr
+	}
+	return err
+}
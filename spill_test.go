@@ -0,0 +1,109 @@
+package iters_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
+)
+
+func ExampleReusableSpill() {
+	spill := iters.ReusableSpill(slices.Values([]string{"a", "b", "c", "d"}), 2, iters.JSONCodec[string]{})
+	defer spill.Close()
+
+	for range 2 {
+		values, err := iters.CollectErr(spill.All())
+		fmt.Println(values, err)
+	}
+	fmt.Println(spill.Len(), spill.Spilled())
+	// Output:
+	// [a b c d] <nil>
+	// [a b c d] <nil>
+	// 4 2
+}
+
+type spillRecord struct {
+	ID   int
+	Name string
+}
+
+func TestReusableSpill(t *testing.T) {
+	records := make([]spillRecord, 50)
+	for i := range records {
+		records[i] = spillRecord{ID: i, Name: strconv.Itoa(i * i)}
+	}
+
+	for _, budget := range []int{0, 10, 100} {
+		t.Run(fmt.Sprint(budget), func(t *testing.T) {
+			src, c := iterstest.Counting(slices.Values(records))
+			spill := iters.ReusableSpill(src, budget, iters.GobCodec[spillRecord]{})
+
+			for range spill.All() {
+				break
+			}
+			for pass := range 2 {
+				got, err := iters.CollectErr(spill.All())
+				if err != nil || !slices.Equal(got, records) {
+					t.Fatalf("ReusableSpill: pass %d expected all records, got %d records and %v", pass, len(got), err)
+				}
+			}
+			if c.Pulled() != len(records) || c.Iterations() != 1 {
+				t.Fatalf("ReusableSpill: expected one pass over the source, got %d iterations pulling %d", c.Iterations(), c.Pulled())
+			}
+			if want := max(len(records)-budget, 0); spill.Spilled() != want {
+				t.Fatalf("ReusableSpill: expected %d spilled, got %d", want, spill.Spilled())
+			}
+			if err := spill.Close(); err != nil {
+				t.Fatalf("ReusableSpill: close: %v", err)
+			}
+			if _, err := iters.CollectErr(spill.All()); !errors.Is(err, os.ErrClosed) {
+				t.Fatalf("ReusableSpill: expected os.ErrClosed after Close, got %v", err)
+			}
+		})
+	}
+}
+
+type failingCodec struct {
+	iters.JSONCodec[int]
+}
+
+func (failingCodec) Encode(v int) ([]byte, error) {
+	if v == 3 {
+		return nil, errors.New("cannot encode 3")
+	}
+	return iters.JSONCodec[int]{}.Encode(v)
+}
+
+func TestReusableSpillEncodeError(t *testing.T) {
+	spill := iters.ReusableSpill(slices.Values([]int{1, 2, 3, 4}), 1, failingCodec{})
+	defer spill.Close()
+
+	for range 2 {
+		got, err := iters.CollectErr(spill.All())
+		if err == nil || !slices.Equal(got, []int{1, 2}) {
+			t.Fatalf("ReusableSpill: expected [1 2] and an error, got %v and %v", got, err)
+		}
+	}
+}
+
+func TestReusableSpillConcurrentConsumers(t *testing.T) {
+	spill := iters.ReusableSpill(iters.Limit(iterstest.Naturals(), 200), 50, iters.JSONCodec[int]{})
+	defer spill.Close()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			got, err := iters.CollectErr(spill.All())
+			if err != nil || len(got) != 200 || !slices.IsSorted(got) {
+				t.Errorf("ReusableSpill: expected 200 ordered elements, got %d and %v", len(got), err)
+			}
+		})
+	}
+	wg.Wait()
+}
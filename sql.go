@@ -0,0 +1,121 @@
+package iters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// Querier is the query method shared by *sql.DB, *sql.Conn and *sql.Tx.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Rows runs query against db and returns a sequence of the resulting rows
+// scanned into values of type T.
+//
+// When T is a struct, each column is scanned into the exported field whose
+// "db" struct tag matches the column name, or whose name matches it
+// case-insensitively when there is no tag. A tag of "-" skips the field.
+// Any column without a matching field is reported as an error. When T is
+// not a struct, the query must return exactly one column, which is scanned
+// directly into T.
+//
+// The rows are closed when the sequence finishes or the consumer stops
+// early. Errors from the query, from scanning, or from [sql.Rows.Err] are
+// yielded once and end the sequence.
+func Rows[T any](ctx context.Context, db Querier, query string, args ...any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		fields, err := sqlFieldsFor(reflect.TypeFor[T](), columns)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		dest := make([]any, len(columns))
+		for rows.Next() {
+			var item T
+			v := reflect.ValueOf(&item).Elem()
+			if fields == nil {
+				dest[0] = &item
+			} else {
+				for i, field := range fields {
+					dest[i] = v.Field(field).Addr().Interface()
+				}
+			}
+
+			if err := rows.Scan(dest...); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// sqlFieldsFor returns the struct field index for each column. It returns a
+// nil slice when t is not a struct and values are scanned directly.
+func sqlFieldsFor(t reflect.Type, columns []string) ([]int, error) {
+	if t.Kind() != reflect.Struct || t.Implements(scannerType) || reflect.PointerTo(t).Implements(scannerType) || t == timeType {
+		if len(columns) != 1 {
+			return nil, fmt.Errorf("iters: sql scanning into %s requires one column, got %d", t, len(columns))
+		}
+		return nil, nil
+	}
+
+	fields := make([]int, len(columns))
+	for i, column := range columns {
+		fields[i] = -1
+		for j := range t.NumField() {
+			sf := t.Field(j)
+			if !sf.IsExported() {
+				continue
+			}
+			if tag, ok := sf.Tag.Lookup("db"); ok {
+				if tag, _, _ = strings.Cut(tag, ","); tag == "-" {
+					continue
+				} else if tag != "" {
+					if tag == column {
+						fields[i] = j
+						break
+					}
+					continue
+				}
+			}
+			if strings.EqualFold(sf.Name, column) {
+				fields[i] = j
+				break
+			}
+		}
+		if fields[i] < 0 {
+			return nil, fmt.Errorf("iters: sql column %q has no matching field in %s", column, t)
+		}
+	}
+	return fields, nil
+}
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
@@ -0,0 +1,272 @@
+package iters_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/picatz/iters"
+)
+
+// fakeDriver is a minimal database/sql driver whose queries return canned
+// results, so Rows can be tested without a real database.
+type fakeDriver struct{}
+
+// fakeResult is the canned result for a query.
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error // returned by Next after all rows
+}
+
+// fakeDB holds the canned results and bookkeeping for a DSN.
+type fakeDB struct {
+	results map[string]fakeResult
+	opened  atomic.Int64
+	closed  atomic.Int64
+	scanned atomic.Int64
+}
+
+var fakeDBs sync.Map // DSN -> *fakeDB
+
+func init() {
+	sql.Register("iters-fake", fakeDriver{})
+}
+
+// openFakeDB registers results under a DSN unique to t and opens it.
+func openFakeDB(t *testing.T, results map[string]fakeResult) (*sql.DB, *fakeDB) {
+	t.Helper()
+
+	state := &fakeDB{results: results}
+	fakeDBs.Store(t.Name(), state)
+	t.Cleanup(func() { fakeDBs.Delete(t.Name()) })
+
+	db, err := sql.Open("iters-fake", t.Name())
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, state
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	state, ok := fakeDBs.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown fake database %q", name)
+	}
+	return &fakeConn{db: state.(*fakeDB)}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	result, ok := s.db.results[s.query]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+	s.db.opened.Add(1)
+	return &fakeRows{db: s.db, result: result}, nil
+}
+
+type fakeRows struct {
+	db     *fakeDB
+	result fakeResult
+	pos    int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+
+func (r *fakeRows) Close() error {
+	r.db.closed.Add(1)
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		if r.result.err != nil {
+			return r.result.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	r.db.scanned.Add(1)
+	return nil
+}
+
+var fakeUsers = fakeResult{
+	columns: []string{"id", "name", "email_address"},
+	rows: [][]driver.Value{
+		{int64(1), "ada", "ada@example.com"},
+		{int64(2), "grace", nil},
+		{int64(3), "linus", "linus@example.com"},
+	},
+}
+
+type fakeUser struct {
+	ID    int64
+	Name  string
+	Email sql.NullString `db:"email_address"`
+	Notes string         `db:"-"`
+}
+
+func TestRows(t *testing.T) {
+	db, state := openFakeDB(t, map[string]fakeResult{"SELECT users": fakeUsers})
+
+	got, err := iters.CollectErr(iters.Rows[fakeUser](context.Background(), db, "SELECT users"))
+	if err != nil {
+		t.Fatalf("Rows: unexpected error %v", err)
+	}
+
+	want := []fakeUser{
+		{ID: 1, Name: "ada", Email: sql.NullString{String: "ada@example.com", Valid: true}},
+		{ID: 2, Name: "grace"},
+		{ID: 3, Name: "linus", Email: sql.NullString{String: "linus@example.com", Valid: true}},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Rows: expected %+v, got %+v", want, got)
+	}
+	if opened, closed := state.opened.Load(), state.closed.Load(); opened != 1 || closed != 1 {
+		t.Fatalf("expected rows to be opened and closed once, got %d and %d", opened, closed)
+	}
+}
+
+func TestRowsScalar(t *testing.T) {
+	db, _ := openFakeDB(t, map[string]fakeResult{
+		"SELECT name": {
+			columns: []string{"name"},
+			rows:    [][]driver.Value{{"ada"}, {"grace"}},
+		},
+		"SELECT pair": {
+			columns: []string{"name", "age"},
+			rows:    [][]driver.Value{{"ada", int64(36)}},
+		},
+	})
+
+	got, err := iters.CollectErr(iters.Rows[string](context.Background(), db, "SELECT name"))
+	if err != nil {
+		t.Fatalf("Rows: unexpected error %v", err)
+	}
+	if want := []string{"ada", "grace"}; !slices.Equal(got, want) {
+		t.Fatalf("Rows: expected %v, got %v", want, got)
+	}
+
+	_, err = iters.CollectErr(iters.Rows[string](context.Background(), db, "SELECT pair"))
+	if err == nil {
+		t.Fatalf("expected an error when scanning several columns into a scalar")
+	}
+}
+
+func TestRowsClosesOnEarlyBreak(t *testing.T) {
+	db, state := openFakeDB(t, map[string]fakeResult{"SELECT users": fakeUsers})
+
+	names := iters.Limit(
+		iters.Map(
+			iters.UntilErr(iters.Rows[fakeUser](context.Background(), db, "SELECT users")),
+			func(u fakeUser) string { return u.Name },
+		),
+		1,
+	)
+	if got, want := slices.Collect(names), []string{"ada"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if closed := state.closed.Load(); closed != 1 {
+		t.Fatalf("expected rows to be closed after an early break, got %d closes", closed)
+	}
+	if scanned := state.scanned.Load(); scanned > 2 {
+		t.Fatalf("expected at most two rows to be read, got %d", scanned)
+	}
+}
+
+func TestRowsReportsErr(t *testing.T) {
+	boom := errors.New("connection reset")
+	result := fakeUsers
+	result.err = boom
+
+	db, state := openFakeDB(t, map[string]fakeResult{"SELECT users": result})
+
+	got, err := iters.CollectErr(iters.Rows[fakeUser](context.Background(), db, "SELECT users"))
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected %v, got %v", boom, err)
+	}
+	if len(got) != len(fakeUsers.rows) {
+		t.Fatalf("expected %d rows before the error, got %d", len(fakeUsers.rows), len(got))
+	}
+	if closed := state.closed.Load(); closed != 1 {
+		t.Fatalf("expected rows to be closed, got %d closes", closed)
+	}
+}
+
+func TestRowsUnmatchedColumn(t *testing.T) {
+	type partialUser struct {
+		ID int64
+	}
+
+	db, state := openFakeDB(t, map[string]fakeResult{"SELECT users": fakeUsers})
+
+	_, err := iters.CollectErr(iters.Rows[partialUser](context.Background(), db, "SELECT users"))
+	if err == nil {
+		t.Fatalf("expected an error for unmatched columns")
+	}
+	if closed := state.closed.Load(); closed != 1 {
+		t.Fatalf("expected rows to be closed, got %d closes", closed)
+	}
+}
+
+func TestRowsScanError(t *testing.T) {
+	type badUser struct {
+		ID    int64
+		Name  int64
+		Email sql.NullString `db:"email_address"`
+	}
+
+	db, _ := openFakeDB(t, map[string]fakeResult{"SELECT users": fakeUsers})
+
+	got, err := iters.CollectErr(iters.Rows[badUser](context.Background(), db, "SELECT users"))
+	if err == nil {
+		t.Fatalf("expected a scan error")
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no rows, got %v", got)
+	}
+}
+
+func TestRowsQueryError(t *testing.T) {
+	db, _ := openFakeDB(t, nil)
+
+	_, err := iters.CollectErr(iters.Rows[fakeUser](context.Background(), db, "SELECT missing"))
+	if err == nil {
+		t.Fatalf("expected the query error to be yielded")
+	}
+}
@@ -161,3 +161,20 @@ func TestStop2(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestStopAcceptsAnyType(t *testing.T) {
+	batches := slices.Values([][]int{{1}, {2, 3}, {}, {4}})
+	got := slices.Collect(iters.Stop(batches, func(b []int) bool { return len(b) == 0 }))
+	if len(got) != 2 {
+		t.Fatalf("Stop: expected 2 batches, got %v", got)
+	}
+
+	handlers := slices.All([]func() int{func() int { return 1 }, nil})
+	count := 0
+	for range iters.Stop2(handlers, func(_ int, fn func() int) bool { return fn == nil }) {
+		count++
+	}
+	if count != 1 {
+		t.Fatalf("Stop2: expected 1 pair, got %d", count)
+	}
+}
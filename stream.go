@@ -0,0 +1,340 @@
+package iters
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// Stream wraps an iter.Seq so that same-type operations can be chained as
+// methods, reading left to right:
+//
+//	top := iters.From(slices.Values(scores)).
+//		Filter(func(s int) bool { return s > 0 }).
+//		SortFunc(cmp.Compare[int]).
+//		Limit(3).
+//		Collect()
+//
+// Go methods cannot introduce type parameters, so steps that change the
+// element type, such as Map, remain package functions that take s.Seq().
+// Operations that need a stricter constraint than any, such as Unique,
+// can be chained with Then.
+//
+// A Stream can be ranged over directly.
+type Stream[T any] iter.Seq[T]
+
+// From returns a Stream over seq.
+func From[T any](seq iter.Seq[T]) Stream[T] {
+	return Stream[T](seq)
+}
+
+// Seq returns the underlying sequence.
+func (s Stream[T]) Seq() iter.Seq[T] {
+	return iter.Seq[T](s)
+}
+
+// Then applies fn to the stream, allowing any same-type function such as
+// Unique or Compact to be used in a chain. fn is called right away, so a
+// function that collects its input when called, such as [Sort], reads the
+// whole stream while the chain is built; use the SortFunc method to sort
+// lazily.
+func (s Stream[T]) Then(fn func(iter.Seq[T]) iter.Seq[T]) Stream[T] {
+	return Stream[T](fn(s.Seq()))
+}
+
+// Filter is the chainable form of [Filter].
+func (s Stream[T]) Filter(fn Predicate[T]) Stream[T] {
+	return Stream[T](Filter(s.Seq(), fn))
+}
+
+// Limit is the chainable form of [Limit].
+func (s Stream[T]) Limit(n int) Stream[T] {
+	return Stream[T](Limit(s.Seq(), n))
+}
+
+// Before is the chainable form of [Before].
+func (s Stream[T]) Before(n int) Stream[T] {
+	return Stream[T](Before(s.Seq(), n))
+}
+
+// BeforeFunc is the chainable form of [BeforeFunc].
+func (s Stream[T]) BeforeFunc(pred Predicate[T]) Stream[T] {
+	return Stream[T](BeforeFunc(s.Seq(), pred))
+}
+
+// After is the chainable form of [After].
+func (s Stream[T]) After(n int) Stream[T] {
+	return Stream[T](After(s.Seq(), n))
+}
+
+// AfterFunc is the chainable form of [AfterFunc].
+func (s Stream[T]) AfterFunc(pred Predicate[T]) Stream[T] {
+	return Stream[T](AfterFunc(s.Seq(), pred))
+}
+
+// Context is the chainable form of [Context].
+func (s Stream[T]) Context(ctx context.Context) Stream[T] {
+	return Stream[T](Context(ctx, s.Seq()))
+}
+
+// Concat is the chainable form of [Concat]; it appends seqs to s.
+func (s Stream[T]) Concat(seqs ...iter.Seq[T]) Stream[T] {
+	return Stream[T](Concat(append([]iter.Seq[T]{s.Seq()}, seqs...)...))
+}
+
+// CompactFunc is the chainable form of [CompactFunc].
+func (s Stream[T]) CompactFunc(equal func(a, b T) bool) Stream[T] {
+	return Stream[T](CompactFunc(s.Seq(), equal))
+}
+
+// UniqueFunc is the chainable form of [UniqueFunc].
+func (s Stream[T]) UniqueFunc(equal func(a, b T) bool) Stream[T] {
+	return Stream[T](UniqueFunc(s.Seq(), equal))
+}
+
+// SortFunc is the chainable form of [SortFunc]. Unlike SortFunc, the input
+// is not collected until the stream is iterated.
+func (s Stream[T]) SortFunc(cmp func(a, b T) int) Stream[T] {
+	return func(yield func(T) bool) {
+		for item := range SortFunc(s.Seq(), cmp) {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Reusable is the chainable form of [Reusable].
+func (s Stream[T]) Reusable() Stream[T] {
+	return Stream[T](Reusable(s.Seq()))
+}
+
+// Chunk is the method form of [Chunk]. It returns a plain sequence, since a
+// Stream[[]T] method on Stream[T] would be an instantiation cycle; wrap the
+// result with From to keep chaining.
+func (s Stream[T]) Chunk(size int) iter.Seq[[]T] {
+	return Chunk(s.Seq(), size)
+}
+
+// ChunkFunc is the method form of [ChunkFunc]. Like Chunk, it returns a
+// plain sequence.
+func (s Stream[T]) ChunkFunc(pred Predicate[T]) iter.Seq[[]T] {
+	return ChunkFunc(s.Seq(), pred)
+}
+
+// Collect gathers the stream into a new slice.
+func (s Stream[T]) Collect() []T {
+	return slices.Collect(s.Seq())
+}
+
+// First is the terminal form of [First].
+func (s Stream[T]) First() (T, bool) {
+	return First(s.Seq())
+}
+
+// FirstFunc is the terminal form of [FirstFunc].
+func (s Stream[T]) FirstFunc(pred Predicate[T]) (T, bool) {
+	return FirstFunc(s.Seq(), pred)
+}
+
+// Last is the terminal form of [Last].
+func (s Stream[T]) Last() (T, bool) {
+	return Last(s.Seq())
+}
+
+// LastFunc is the terminal form of [LastFunc].
+func (s Stream[T]) LastFunc(pred Predicate[T]) (T, bool) {
+	return LastFunc(s.Seq(), pred)
+}
+
+// Reduce is the terminal form of [Reduce] for accumulators of the element
+// type. Use the Reduce function to accumulate into a different type.
+func (s Stream[T]) Reduce(fn Reducer[T, T], initial T) T {
+	return Reduce(s.Seq(), fn, initial)
+}
+
+// Count is the terminal form of [Count].
+func (s Stream[T]) Count() int {
+	return Count(s.Seq())
+}
+
+// CountFunc is the terminal form of [CountFunc].
+func (s Stream[T]) CountFunc(pred Predicate[T]) int {
+	return CountFunc(s.Seq(), pred)
+}
+
+// ContainsFunc is the terminal form of [ContainsFunc].
+func (s Stream[T]) ContainsFunc(fn Predicate[T]) bool {
+	return ContainsFunc(s.Seq(), fn)
+}
+
+// MaxFunc is the terminal form of [MaxFunc].
+func (s Stream[T]) MaxFunc(less func(a, b T) bool) (T, bool) {
+	return MaxFunc(s.Seq(), less)
+}
+
+// MinFunc is the terminal form of [MinFunc].
+func (s Stream[T]) MinFunc(less func(a, b T) bool) (T, bool) {
+	return MinFunc(s.Seq(), less)
+}
+
+// ForEach calls fn for every element of the stream.
+func (s Stream[T]) ForEach(fn func(T)) {
+	for item := range s {
+		fn(item)
+	}
+}
+
+// Stream2 is the keyed companion to Stream, wrapping an iter.Seq2 so that
+// same-type operations can be chained as methods.
+type Stream2[K, V any] iter.Seq2[K, V]
+
+// From2 returns a Stream2 over seq2.
+func From2[K, V any](seq2 iter.Seq2[K, V]) Stream2[K, V] {
+	return Stream2[K, V](seq2)
+}
+
+// Seq2 returns the underlying sequence.
+func (s Stream2[K, V]) Seq2() iter.Seq2[K, V] {
+	return iter.Seq2[K, V](s)
+}
+
+// Then applies fn to the stream, allowing any same-type function such as
+// Compact2 to be used in a chain.
+func (s Stream2[K, V]) Then(fn func(iter.Seq2[K, V]) iter.Seq2[K, V]) Stream2[K, V] {
+	return Stream2[K, V](fn(s.Seq2()))
+}
+
+// Filter is the chainable form of [Filter2].
+func (s Stream2[K, V]) Filter(fn Predicate2[K, V]) Stream2[K, V] {
+	return Stream2[K, V](Filter2(s.Seq2(), fn))
+}
+
+// Limit is the chainable form of [Limit2].
+func (s Stream2[K, V]) Limit(n int) Stream2[K, V] {
+	return Stream2[K, V](Limit2(s.Seq2(), n))
+}
+
+// Before is the chainable form of [Before2].
+func (s Stream2[K, V]) Before(n int) Stream2[K, V] {
+	return Stream2[K, V](Before2(s.Seq2(), n))
+}
+
+// BeforeFunc is the chainable form of [BeforeFunc2].
+func (s Stream2[K, V]) BeforeFunc(pred Predicate2[K, V]) Stream2[K, V] {
+	return Stream2[K, V](BeforeFunc2(s.Seq2(), pred))
+}
+
+// After is the chainable form of [After2].
+func (s Stream2[K, V]) After(n int) Stream2[K, V] {
+	return Stream2[K, V](After2(s.Seq2(), n))
+}
+
+// AfterFunc is the chainable form of [AfterFunc2].
+func (s Stream2[K, V]) AfterFunc(pred Predicate2[K, V]) Stream2[K, V] {
+	return Stream2[K, V](AfterFunc2(s.Seq2(), pred))
+}
+
+// Context is the chainable form of [Context2].
+func (s Stream2[K, V]) Context(ctx context.Context) Stream2[K, V] {
+	return Stream2[K, V](Context2(ctx, s.Seq2()))
+}
+
+// Concat is the chainable form of [Concat2]; it appends seqs2 to s.
+func (s Stream2[K, V]) Concat(seqs2 ...iter.Seq2[K, V]) Stream2[K, V] {
+	return Stream2[K, V](Concat2(append([]iter.Seq2[K, V]{s.Seq2()}, seqs2...)...))
+}
+
+// CompactFunc is the chainable form of [CompactFunc2].
+func (s Stream2[K, V]) CompactFunc(equal func(aK K, aV V, bK K, bV V) bool) Stream2[K, V] {
+	return Stream2[K, V](CompactFunc2(s.Seq2(), equal))
+}
+
+// UniqueFunc is the chainable form of [UniqueFunc2].
+func (s Stream2[K, V]) UniqueFunc(equal func(k1 K, v1 V, k2 K, v2 V) bool) Stream2[K, V] {
+	return Stream2[K, V](UniqueFunc2(s.Seq2(), equal))
+}
+
+// SortFunc is the chainable form of [SortFunc2].
+func (s Stream2[K, V]) SortFunc(cmp func(k1 K, v1 V, k2 K, v2 V) int) Stream2[K, V] {
+	return Stream2[K, V](SortFunc2(s.Seq2(), cmp))
+}
+
+// Reusable is the chainable form of [Reusable2].
+func (s Stream2[K, V]) Reusable() Stream2[K, V] {
+	return Stream2[K, V](Reusable2(s.Seq2()))
+}
+
+// Chunk is the method form of [Chunk2]. Like Stream.Chunk, it returns a
+// plain sequence; wrap the result with From2 to keep chaining.
+func (s Stream2[K, V]) Chunk(size int) iter.Seq2[[]K, []V] {
+	return Chunk2(s.Seq2(), size)
+}
+
+// ChunkFunc is the method form of [ChunkFunc2]. Like Chunk, it returns a
+// plain sequence.
+func (s Stream2[K, V]) ChunkFunc(pred Predicate2[K, V]) iter.Seq2[[]K, []V] {
+	return ChunkFunc2(s.Seq2(), pred)
+}
+
+// Keys is the chainable form of [Keys].
+func (s Stream2[K, V]) Keys() Stream[K] {
+	return Stream[K](Keys(s.Seq2()))
+}
+
+// Values is the chainable form of [Values].
+func (s Stream2[K, V]) Values() Stream[V] {
+	return Stream[V](Values(s.Seq2()))
+}
+
+// First is the terminal form of [First2].
+func (s Stream2[K, V]) First() (K, V, bool) {
+	return First2(s.Seq2())
+}
+
+// FirstFunc is the terminal form of [FirstFunc2].
+func (s Stream2[K, V]) FirstFunc(pred Predicate2[K, V]) (K, V, bool) {
+	return FirstFunc2(s.Seq2(), pred)
+}
+
+// Last is the terminal form of [Last2].
+func (s Stream2[K, V]) Last() (K, V, bool) {
+	return Last2(s.Seq2())
+}
+
+// LastFunc is the terminal form of [LastFunc2].
+func (s Stream2[K, V]) LastFunc(pred Predicate2[K, V]) (K, V, bool) {
+	return LastFunc2(s.Seq2(), pred)
+}
+
+// Count is the terminal form of [Count2].
+func (s Stream2[K, V]) Count() int {
+	return Count2(s.Seq2())
+}
+
+// CountFunc is the terminal form of [CountFunc2].
+func (s Stream2[K, V]) CountFunc(pred Predicate2[K, V]) int {
+	return CountFunc2(s.Seq2(), pred)
+}
+
+// ContainsFunc is the terminal form of [ContainsFunc2].
+func (s Stream2[K, V]) ContainsFunc(fn Predicate2[K, V]) bool {
+	return ContainsFunc2(s.Seq2(), fn)
+}
+
+// MaxFunc is the terminal form of [MaxFunc2].
+func (s Stream2[K, V]) MaxFunc(less func(k1 K, v1 V, k2 K, v2 V) bool) (K, V, bool) {
+	return MaxFunc2(s.Seq2(), less)
+}
+
+// MinFunc is the terminal form of [MinFunc2].
+func (s Stream2[K, V]) MinFunc(less func(k1 K, v1 V, k2 K, v2 V) bool) (K, V, bool) {
+	return MinFunc2(s.Seq2(), less)
+}
+
+// ForEach calls fn for every pair in the stream.
+func (s Stream2[K, V]) ForEach(fn func(K, V)) {
+	for k, v := range s {
+		fn(k, v)
+	}
+}
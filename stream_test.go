@@ -0,0 +1,245 @@
+package iters_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+)
+
+func ExampleStream() {
+	words := []string{"kiwi", "apple", "fig", "banana", "cherry", "apple", "date"}
+
+	top := iters.From(slices.Values(words)).
+		Filter(func(w string) bool { return len(w) > 3 }).
+		Then(iters.Unique).
+		SortFunc(strings.Compare).
+		Limit(3).
+		Collect()
+
+	fmt.Println(top)
+	// Output:
+	// [apple banana cherry]
+}
+
+func ExampleStream_map() {
+	lengths := iters.From(iters.Map(
+		iters.From(slices.Values([]string{"a", "bb", "ccc"})).
+			Filter(func(s string) bool { return s != "bb" }).
+			Seq(),
+		func(s string) int { return len(s) },
+	))
+
+	fmt.Println(lengths.Reduce(func(acc, n int) int { return acc + n }, 0))
+	// Output:
+	// 4
+}
+
+func ExampleStream2() {
+	stock := map[string]int{"apples": 3, "pears": 0, "plums": 7}
+
+	inStock := iters.From2(maps.All(stock)).
+		Filter(func(_ string, n int) bool { return n > 0 }).
+		Keys().
+		SortFunc(strings.Compare).
+		Collect()
+
+	fmt.Println(inStock)
+	// Output:
+	// [apples plums]
+}
+
+func TestStreamChaining(t *testing.T) {
+	numbers := iters.From(slices.Values([]int{5, 1, 4, 1, 2, 8, 3, 3, 9}))
+
+	tests := []struct {
+		name     string
+		stream   iters.Stream[int]
+		expected []int
+	}{
+		{"filter", numbers.Filter(func(n int) bool { return n%2 == 0 }), []int{4, 2, 8}},
+		{"limit", numbers.Limit(2), []int{5, 1}},
+		{"before", numbers.Before(3), []int{5, 1, 4}},
+		{"before func", numbers.BeforeFunc(func(n int) bool { return n > 4 && n != 5 }), []int{5, 1, 4, 1, 2}},
+		{"after", numbers.After(6), []int{3, 3, 9}},
+		{"after func", numbers.AfterFunc(func(n int) bool { return n != 8 }), []int{8, 3, 3, 9}},
+		{"concat", numbers.Limit(1).Concat(slices.Values([]int{0}), slices.Values([]int{7})), []int{5, 0, 7}},
+		{"compact func", numbers.CompactFunc(func(a, b int) bool { return a == b }), []int{5, 1, 4, 1, 2, 8, 3, 9}},
+		{"unique func", numbers.UniqueFunc(func(a, b int) bool { return a == b }), []int{5, 1, 4, 2, 8, 3, 9}},
+		{"sort func", numbers.SortFunc(func(a, b int) int { return b - a }).Limit(3), []int{9, 8, 5}},
+		{"then", numbers.Then(iters.Compact[int]).Then(iters.Unique[int]), []int{5, 1, 4, 2, 8, 3, 9}},
+		{"reusable", numbers.Limit(2).Reusable(), []int{5, 1}},
+		{"context", numbers.Context(context.Background()).Limit(1), []int{5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.stream.Collect(); !slices.Equal(got, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestStreamSortFuncIsLazy(t *testing.T) {
+	pulled := 0
+	source := iters.From(func(yield func(int) bool) {
+		for _, n := range []int{3, 1, 2} {
+			pulled++
+			if !yield(n) {
+				return
+			}
+		}
+	})
+
+	sorted := source.SortFunc(func(a, b int) int { return a - b })
+	if pulled != 0 {
+		t.Fatalf("expected SortFunc to defer collecting, pulled %d", pulled)
+	}
+	if got, want := sorted.Collect(), []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestStreamChunk(t *testing.T) {
+	chunks := iters.From(iters.From(slices.Values([]int{1, 2, 3, 4, 5})).Chunk(2)).Collect()
+	if len(chunks) != 3 || !slices.Equal(chunks[2], []int{5}) {
+		t.Fatalf("unexpected chunks %v", chunks)
+	}
+
+	runs := iters.From(iters.From(slices.Values([]int{1, 2, 0, 3, 0})).ChunkFunc(func(n int) bool { return n == 0 })).Count()
+	if runs != 3 {
+		t.Fatalf("expected 3 chunks, got %d", runs)
+	}
+}
+
+func TestStreamTerminals(t *testing.T) {
+	s := iters.From(slices.Values([]string{"go", "iter", "seq", "stream"}))
+
+	if v, ok := s.First(); !ok || v != "go" {
+		t.Fatalf("First: got %q %t", v, ok)
+	}
+	if v, ok := s.FirstFunc(func(w string) bool { return len(w) == 3 }); !ok || v != "seq" {
+		t.Fatalf("FirstFunc: got %q %t", v, ok)
+	}
+	if v, ok := s.Last(); !ok || v != "stream" {
+		t.Fatalf("Last: got %q %t", v, ok)
+	}
+	if v, ok := s.LastFunc(func(w string) bool { return len(w) < 4 }); !ok || v != "seq" {
+		t.Fatalf("LastFunc: got %q %t", v, ok)
+	}
+	if got := s.Reduce(func(acc, w string) string { return acc + w[:1] }, ""); got != "giss" {
+		t.Fatalf("Reduce: got %q", got)
+	}
+	if got := s.Count(); got != 4 {
+		t.Fatalf("Count: got %d", got)
+	}
+	if !s.ContainsFunc(func(w string) bool { return strings.HasPrefix(w, "it") }) {
+		t.Fatalf("ContainsFunc: expected a match")
+	}
+	if v, ok := s.MaxFunc(func(a, b string) bool { return len(a) < len(b) }); !ok || v != "stream" {
+		t.Fatalf("MaxFunc: got %q %t", v, ok)
+	}
+	if v, ok := s.MinFunc(func(a, b string) bool { return len(a) < len(b) }); !ok || v != "go" {
+		t.Fatalf("MinFunc: got %q %t", v, ok)
+	}
+
+	var seen []string
+	s.Limit(2).ForEach(func(w string) { seen = append(seen, w) })
+	if want := []string{"go", "iter"}; !slices.Equal(seen, want) {
+		t.Fatalf("ForEach: expected %v, got %v", want, seen)
+	}
+
+	var ranged []string
+	for w := range s.Limit(1) {
+		ranged = append(ranged, w)
+	}
+	if want := []string{"go"}; !slices.Equal(ranged, want) {
+		t.Fatalf("range: expected %v, got %v", want, ranged)
+	}
+}
+
+// orderedPairs yields the sorted keys of m with their values.
+func orderedPairs(m map[string]int) iters.Stream2[string, int] {
+	return func(yield func(string, int) bool) {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
+
+func TestStream2(t *testing.T) {
+	s := orderedPairs(map[string]int{"a": 1, "b": 2, "c": 2, "d": 4})
+
+	if got := maps.Collect(s.Filter(func(_ string, v int) bool { return v%2 == 0 }).Limit(2).Seq2()); !maps.Equal(got, map[string]int{"b": 2, "c": 2}) {
+		t.Fatalf("Filter/Limit: got %v", got)
+	}
+	if got := s.Keys().Collect(); !slices.Equal(got, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("Keys: got %v", got)
+	}
+	if got := s.Values().Collect(); !slices.Equal(got, []int{1, 2, 2, 4}) {
+		t.Fatalf("Values: got %v", got)
+	}
+	if got := s.CompactFunc(func(_ string, a int, _ string, b int) bool { return a == b }).Count(); got != 3 {
+		t.Fatalf("CompactFunc: got %d pairs", got)
+	}
+	if got := s.Concat(orderedPairs(map[string]int{"e": 5}).Seq2()).Count(); got != 5 {
+		t.Fatalf("Concat: got %d pairs", got)
+	}
+	if got := s.Then(iters.Compact2[string, int]).Context(context.Background()).Reusable().Count(); got != 4 {
+		t.Fatalf("Then: got %d pairs", got)
+	}
+
+	var keys [][]string
+	iters.From2(s.Chunk(3)).ForEach(func(k []string, _ []int) { keys = append(keys, k) })
+	if len(keys) != 2 || !slices.Equal(keys[1], []string{"d"}) {
+		t.Fatalf("Chunk: got %v", keys)
+	}
+	if got := iters.From2(s.ChunkFunc(func(_ string, v int) bool { return v == 2 })).Count(); got != 3 {
+		t.Fatalf("ChunkFunc: got %d chunks", got)
+	}
+
+	if k, v, ok := s.First(); !ok || k != "a" || v != 1 {
+		t.Fatalf("First: got %q %d %t", k, v, ok)
+	}
+	if k, _, ok := s.FirstFunc(func(_ string, v int) bool { return v > 1 }); !ok || k != "b" {
+		t.Fatalf("FirstFunc: got %q %t", k, ok)
+	}
+	if k, _, ok := s.Last(); !ok || k != "d" {
+		t.Fatalf("Last: got %q %t", k, ok)
+	}
+	if k, _, ok := s.LastFunc(func(_ string, v int) bool { return v == 2 }); !ok || k != "c" {
+		t.Fatalf("LastFunc: got %q %t", k, ok)
+	}
+	if !s.ContainsFunc(func(k string, v int) bool { return k == "d" && v == 4 }) {
+		t.Fatalf("ContainsFunc: expected a match")
+	}
+
+	if got := s.After(1).Before(2).Keys().Collect(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Fatalf("After/Before: got %v", got)
+	}
+	isSmall := func(_ string, v int) bool { return v < 2 }
+	if got := s.AfterFunc(isSmall).BeforeFunc(func(_ string, v int) bool { return v > 2 }).Keys().Collect(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Fatalf("AfterFunc/BeforeFunc: got %v", got)
+	}
+	byValueDesc := func(_ string, a int, _ string, b int) int { return b - a }
+	if got := s.SortFunc(byValueDesc).Keys().Collect(); !slices.Equal(got, []string{"d", "b", "c", "a"}) {
+		t.Fatalf("SortFunc: got %v", got)
+	}
+	if got := s.UniqueFunc(func(_ string, a int, _ string, b int) bool { return a == b }).Count(); got != 3 {
+		t.Fatalf("UniqueFunc: got %d pairs", got)
+	}
+	byValue := func(_ string, a int, _ string, b int) bool { return a < b }
+	if k, _, ok := s.MaxFunc(byValue); !ok || k != "d" {
+		t.Fatalf("MaxFunc: got %q %t", k, ok)
+	}
+	if k, _, ok := s.MinFunc(byValue); !ok || k != "a" {
+		t.Fatalf("MinFunc: got %q %t", k, ok)
+	}
+}
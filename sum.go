@@ -0,0 +1,63 @@
+package iters
+
+import "iter"
+
+// Integer matches any built-in integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Sum returns the sum of the elements of seq in their own type, or 0 if
+// seq is empty. Integer sums wrap around on overflow, as Go's + does; use
+// CheckedSum to detect it.
+func Sum[T Number](seq iter.Seq[T]) T {
+	var sum T
+	for item := range seq {
+		sum += item
+	}
+	return sum
+}
+
+// Prod returns the product of the elements of seq in their own type, or 1
+// if seq is empty. Like Sum, integer products wrap around on overflow.
+// (Product is the Cartesian product of several sequences.)
+func Prod[T Number](seq iter.Seq[T]) T {
+	product := T(1)
+	for item := range seq {
+		product *= item
+	}
+	return product
+}
+
+// CheckedSum returns the sum of the integers in seq. If adding an element
+// would overflow T, CheckedSum stops reading seq and returns the sum of the
+// elements before it with ok set to false.
+func CheckedSum[T Integer](seq iter.Seq[T]) (sum T, ok bool) {
+	for item := range seq {
+		next := sum + item
+		// Adding a non-negative number must not decrease the sum, and
+		// adding a negative one must not increase it. Unsigned items are
+		// never negative, so this covers both kinds of integer.
+		if (item >= 0 && next < sum) || (item < 0 && next > sum) {
+			return sum, false
+		}
+		sum = next
+	}
+	return sum, true
+}
+
+// Sum2 returns the sum of the values of seq2, as Sum does.
+func Sum2[K any, V Number](seq2 iter.Seq2[K, V]) V {
+	return Sum(Values(seq2))
+}
+
+// Prod2 returns the product of the values of seq2, as Prod does.
+func Prod2[K any, V Number](seq2 iter.Seq2[K, V]) V {
+	return Prod(Values(seq2))
+}
+
+// CheckedSum2 returns the sum of the values of seq2, as CheckedSum does.
+func CheckedSum2[K any, V Integer](seq2 iter.Seq2[K, V]) (sum V, ok bool) {
+	return CheckedSum(Values(seq2))
+}
@@ -0,0 +1,111 @@
+package iters_test
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest/gen"
+)
+
+func ExampleSum() {
+	fmt.Println(iters.Sum(slices.Values([]int{1, 2, 3, 4})))
+	fmt.Println(iters.Sum(slices.Values([]time.Duration{time.Second, 500 * time.Millisecond})))
+	// Output:
+	// 10
+	// 1.5s
+}
+
+func ExampleCheckedSum() {
+	fmt.Println(iters.CheckedSum(slices.Values([]int8{100, 20, 7})))
+	fmt.Println(iters.CheckedSum(slices.Values([]int8{100, 20, 8})))
+	// Output:
+	// 127 true
+	// 120 false
+}
+
+func TestSumAndProd(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(-9, 9), 10), func(s []int) bool {
+		sum, prod := 0, 1
+		for _, n := range s {
+			sum += n
+			prod *= n
+		}
+		return iters.Sum(slices.Values(s)) == sum && iters.Prod(slices.Values(s)) == prod &&
+			iters.Sum2(slices.All(s)) == sum && iters.Prod2(slices.All(s)) == prod
+	})
+
+	if got := iters.Sum(slices.Values([]float64(nil))); got != 0 {
+		t.Fatalf("Sum: expected 0, got %v", got)
+	}
+	if got := iters.Prod(slices.Values([]float64(nil))); got != 1 {
+		t.Fatalf("Prod: expected 1, got %v", got)
+	}
+	if got := iters.Prod(slices.Values([]float64{0.5, 4, 1.5})); got != 3 {
+		t.Fatalf("Prod: expected 3, got %v", got)
+	}
+}
+
+func TestCheckedSum(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int64
+		want  int64
+		ok    bool
+	}{
+		{"empty", nil, 0, true},
+		{"no overflow", []int64{1, -2, 3}, 2, true},
+		{"reaches max", []int64{math.MaxInt64 - 1, 1}, math.MaxInt64, true},
+		{"positive overflow", []int64{math.MaxInt64, 1, -5}, math.MaxInt64, false},
+		{"reaches min", []int64{math.MinInt64 + 1, -1}, math.MinInt64, true},
+		{"negative overflow", []int64{-2, math.MinInt64}, -2, false},
+		{"cancels out", []int64{math.MaxInt64, math.MinInt64}, -1, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := iters.CheckedSum(slices.Values(test.input))
+			if got != test.want || ok != test.ok {
+				t.Fatalf("CheckedSum: expected (%v,%v), got (%v,%v)", test.want, test.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestCheckedSumUnsigned(t *testing.T) {
+	if got, ok := iters.CheckedSum(slices.Values([]uint8{200, 55})); got != 255 || !ok {
+		t.Fatalf("CheckedSum: expected (255,true), got (%v,%v)", got, ok)
+	}
+	if got, ok := iters.CheckedSum(slices.Values([]uint8{200, 56})); got != 200 || ok {
+		t.Fatalf("CheckedSum: expected (200,false), got (%v,%v)", got, ok)
+	}
+
+	m := map[string]uint16{"a": math.MaxUint16, "b": 1}
+	if _, ok := iters.CheckedSum2(maps.All(m)); ok {
+		t.Fatalf("CheckedSum2: expected overflow to be reported")
+	}
+}
+
+func TestCheckedSumMatchesWideSum(t *testing.T) {
+	gen.ForAll(t, gen.Slice(gen.Int(-128, 127), 8), func(s []int) bool {
+		narrow := make([]int8, len(s))
+		for i, n := range s {
+			narrow[i] = int8(n)
+		}
+		got, ok := iters.CheckedSum(slices.Values(narrow))
+
+		// Replay the sum in a wider type to find where it leaves the
+		// range of int8.
+		wide := 0
+		for _, n := range s {
+			if wide+n > math.MaxInt8 || wide+n < math.MinInt8 {
+				return !ok && int(got) == wide
+			}
+			wide += n
+		}
+		return ok && int(got) == wide
+	})
+}
@@ -0,0 +1,184 @@
+package iters
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"time"
+)
+
+// TraceOptions configures the Trace family of functions.
+type TraceOptions struct {
+	// Level is the level elements are logged at. It defaults to
+	// slog.LevelDebug.
+	Level slog.Leveler
+
+	// SummaryLevel is the level of the summary logged when iteration ends.
+	// It defaults to slog.LevelInfo.
+	SummaryLevel slog.Leveler
+
+	// Every samples the elements so that only every Nth one is logged,
+	// starting with the first. Values below 2 log every element. Errors
+	// are always logged.
+	Every int
+}
+
+// tracer holds the state of a single traced iteration.
+type tracer struct {
+	ctx     context.Context
+	logger  *slog.Logger
+	name    string
+	level   slog.Level
+	summary slog.Level
+	every   int64
+	start   time.Time
+	count   int64
+	errors  int64
+	stopped bool
+}
+
+func newTracer(logger *slog.Logger, name string, opts *TraceOptions) *tracer {
+	t := &tracer{
+		ctx:     context.Background(),
+		logger:  logger,
+		name:    name,
+		level:   slog.LevelDebug,
+		summary: slog.LevelInfo,
+		every:   1,
+		start:   time.Now(),
+	}
+	if opts != nil {
+		if opts.Level != nil {
+			t.level = opts.Level.Level()
+		}
+		if opts.SummaryLevel != nil {
+			t.summary = opts.SummaryLevel.Level()
+		}
+		if opts.Every > 1 {
+			t.every = int64(opts.Every)
+		}
+	}
+	return t
+}
+
+// sampled reports whether the element just counted should be logged.
+func (t *tracer) sampled() bool {
+	return (t.count-1)%t.every == 0 && t.logger.Enabled(t.ctx, t.level)
+}
+
+func (t *tracer) element(attrs []slog.Attr) {
+	t.logger.LogAttrs(t.ctx, t.level, "sequence element",
+		append([]slog.Attr{slog.String("sequence", t.name), slog.Int64("index", t.count-1)}, attrs...)...)
+}
+
+func (t *tracer) error(err error) {
+	t.errors++
+	t.logger.LogAttrs(t.ctx, slog.LevelError, "sequence error",
+		slog.String("sequence", t.name), slog.Int64("index", t.count-1), slog.Any("error", err))
+}
+
+func (t *tracer) done(withErrors bool) {
+	attrs := []slog.Attr{
+		slog.String("sequence", t.name),
+		slog.Int64("count", t.count),
+		slog.Duration("duration", time.Since(t.start)),
+		slog.Bool("stopped", t.stopped),
+	}
+	if withErrors {
+		attrs = append(attrs, slog.Int64("errors", t.errors))
+	}
+	t.logger.LogAttrs(t.ctx, t.summary, "sequence done", attrs...)
+}
+
+// Trace returns a sequence that yields the elements of seq unchanged while
+// logging them to logger, as described by TraceFunc. Each element is
+// logged with a "value" attribute.
+func Trace[T any](seq iter.Seq[T], logger *slog.Logger, name string, opts *TraceOptions) iter.Seq[T] {
+	return TraceFunc(seq, logger, name, opts, func(item T) []slog.Attr {
+		return []slog.Attr{slog.Any("value", item)}
+	})
+}
+
+// TraceFunc returns a sequence that yields the elements of seq unchanged
+// while logging them to logger. Sampled elements are logged with the
+// message "sequence element", the sequence name, the element index and the
+// attributes returned by attrs, which is only called for elements that are
+// logged. When iteration ends, a "sequence done" summary reports the number
+// of elements, the duration and whether the consumer stopped early. A nil
+// opts uses the defaults.
+func TraceFunc[T any](seq iter.Seq[T], logger *slog.Logger, name string, opts *TraceOptions, attrs func(T) []slog.Attr) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t := newTracer(logger, name, opts)
+		defer t.done(false)
+
+		for item := range seq {
+			t.count++
+			if t.sampled() {
+				t.element(attrs(item))
+			}
+			if !yield(item) {
+				t.stopped = true
+				return
+			}
+		}
+	}
+}
+
+// Trace2 is the keyed companion to Trace; each pair is logged with "key"
+// and "value" attributes.
+func Trace2[K, V any](seq2 iter.Seq2[K, V], logger *slog.Logger, name string, opts *TraceOptions) iter.Seq2[K, V] {
+	return TraceFunc2(seq2, logger, name, opts, func(k K, v V) []slog.Attr {
+		return []slog.Attr{slog.Any("key", k), slog.Any("value", v)}
+	})
+}
+
+// TraceFunc2 is the keyed companion to TraceFunc.
+func TraceFunc2[K, V any](seq2 iter.Seq2[K, V], logger *slog.Logger, name string, opts *TraceOptions, attrs func(K, V) []slog.Attr) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t := newTracer(logger, name, opts)
+		defer t.done(false)
+
+		for k, v := range seq2 {
+			t.count++
+			if t.sampled() {
+				t.element(attrs(k, v))
+			}
+			if !yield(k, v) {
+				t.stopped = true
+				return
+			}
+		}
+	}
+}
+
+// TraceErr traces a sequence of values and errors, such as those accepted
+// by CollectErr. Values are logged with a "value" attribute as by Trace.
+func TraceErr[T any](seq iter.Seq2[T, error], logger *slog.Logger, name string, opts *TraceOptions) iter.Seq2[T, error] {
+	return TraceErrFunc(seq, logger, name, opts, func(item T) []slog.Attr {
+		return []slog.Attr{slog.Any("value", item)}
+	})
+}
+
+// TraceErrFunc behaves like TraceFunc for a sequence of values and errors.
+// Non-nil errors are always logged at slog.LevelError with the message
+// "sequence error", regardless of sampling, and the summary also reports
+// the number of errors seen.
+func TraceErrFunc[T any](seq iter.Seq2[T, error], logger *slog.Logger, name string, opts *TraceOptions, attrs func(T) []slog.Attr) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		t := newTracer(logger, name, opts)
+		defer t.done(true)
+
+		for item, err := range seq {
+			t.count++
+			if err != nil {
+				t.error(err)
+			} else if t.sampled() {
+				t.element(attrs(item))
+			}
+			if !yield(item, err) {
+				t.stopped = true
+				return
+			}
+		}
+	}
+}
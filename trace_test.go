@@ -0,0 +1,158 @@
+package iters_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/picatz/iters"
+)
+
+// newTraceLogger returns a logger writing text records without times, so
+// that output is stable.
+func newTraceLogger(w *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == "duration") {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func ExampleTrace() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	seq := iters.Trace(slices.Values([]string{"a", "b", "c"}), logger, "letters", nil)
+	for s := range seq {
+		if s == "b" {
+			break
+		}
+	}
+	// Output:
+	// level=DEBUG msg="sequence element" sequence=letters index=0 value=a
+	// level=DEBUG msg="sequence element" sequence=letters index=1 value=b
+	// level=INFO msg="sequence done" sequence=letters count=2 stopped=true
+}
+
+func TestTraceSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTraceLogger(&buf, slog.LevelDebug)
+
+	seq := iters.Trace(slices.Values([]int{0, 1, 2, 3, 4, 5, 6}), logger, "numbers", &iters.TraceOptions{Every: 3})
+	if got := slices.Collect(seq); len(got) != 7 {
+		t.Fatalf("expected all elements to pass through, got %v", got)
+	}
+
+	want := strings.Join([]string{
+		`level=DEBUG msg="sequence element" sequence=numbers index=0 value=0`,
+		`level=DEBUG msg="sequence element" sequence=numbers index=3 value=3`,
+		`level=DEBUG msg="sequence element" sequence=numbers index=6 value=6`,
+		`level=INFO msg="sequence done" sequence=numbers count=7 stopped=false`,
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestTraceLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTraceLogger(&buf, slog.LevelInfo)
+
+	extracted := 0
+	seq := iters.TraceFunc(slices.Values([]int{1, 2}), logger, "quiet", nil, func(int) []slog.Attr {
+		extracted++
+		return nil
+	})
+	for range seq {
+	}
+	if extracted != 0 {
+		t.Fatalf("expected attrs not to be computed for disabled levels, got %d calls", extracted)
+	}
+	if want := "level=INFO msg=\"sequence done\" sequence=quiet count=2 stopped=false\n"; buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	opts := &iters.TraceOptions{Level: slog.LevelWarn, SummaryLevel: slog.LevelDebug}
+	for range iters.Trace(slices.Values([]int{1}), logger, "loud", opts) {
+	}
+	if want := "level=WARN msg=\"sequence element\" sequence=loud index=0 value=1\n"; buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestTraceFuncAttrs(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	var buf bytes.Buffer
+	logger := newTraceLogger(&buf, slog.LevelDebug)
+
+	seq := iters.TraceFunc(slices.Values([]user{{1, "ada"}}), logger, "users", nil, func(u user) []slog.Attr {
+		return []slog.Attr{slog.Int("id", u.ID)}
+	})
+	for range seq {
+	}
+
+	if !strings.Contains(buf.String(), "sequence=users index=0 id=1\n") {
+		t.Fatalf("expected custom attributes, got %q", buf.String())
+	}
+}
+
+func TestTrace2(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTraceLogger(&buf, slog.LevelDebug)
+
+	seq := iters.Trace2(iters.Zip(slices.Values([]string{"a", "b"}), slices.Values([]int{1, 2})), logger, "pairs", nil)
+	for range seq {
+	}
+
+	want := strings.Join([]string{
+		`level=DEBUG msg="sequence element" sequence=pairs index=0 key=a value=1`,
+		`level=DEBUG msg="sequence element" sequence=pairs index=1 key=b value=2`,
+		`level=INFO msg="sequence done" sequence=pairs count=2 stopped=false`,
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestTraceErr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTraceLogger(&buf, slog.LevelDebug)
+
+	source := func(yield func(int, error) bool) {
+		_ = yield(1, nil) && yield(0, errors.New("boom")) && yield(3, nil)
+	}
+
+	values, err := iters.CollectErr(iters.TraceErr(source, logger, "results", &iters.TraceOptions{Every: 5}))
+	if err == nil || !slices.Equal(values, []int{1}) {
+		t.Fatalf("unexpected result %v, %v", values, err)
+	}
+
+	want := strings.Join([]string{
+		`level=DEBUG msg="sequence element" sequence=results index=0 value=1`,
+		`level=ERROR msg="sequence error" sequence=results index=1 error=boom`,
+		`level=INFO msg="sequence done" sequence=results count=2 stopped=true errors=1`,
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+}
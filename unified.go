@@ -0,0 +1,116 @@
+package iters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// UnifiedOptions configures WriteUnified and Unified.
+type UnifiedOptions struct {
+	// FromFile and ToFile name the two sequences in the header. They
+	// default to "a" and "b".
+	FromFile, ToFile string
+
+	// Context is the number of kept elements shown around each change. It
+	// defaults to 3; a negative value shows none.
+	Context int
+}
+
+// WriteUnified writes edits to w in the unified diff format used by diff -u
+// and git, with each element formatted by fmt.Sprint on its own line.
+// Elements are expected not to contain newlines. Nothing is written when
+// edits contains no changes.
+//
+// The edits are collected before anything is written, so that changes
+// close together can be grouped into a single hunk.
+func WriteUnified[T any](w io.Writer, edits iter.Seq[Edit[T]], opts *UnifiedOptions) error {
+	from, to, context := "a", "b", 3
+	if opts != nil {
+		if opts.FromFile != "" {
+			from = opts.FromFile
+		}
+		if opts.ToFile != "" {
+			to = opts.ToFile
+		}
+		switch {
+		case opts.Context > 0:
+			context = opts.Context
+		case opts.Context < 0:
+			context = 0
+		}
+	}
+
+	script := slices.Collect(edits)
+
+	// Each hunk covers script[lo:hi]: its changes and the kept elements
+	// around them. Hunks whose context would touch or overlap are merged.
+	var hunks [][2]int
+	for i, e := range script {
+		if e.Kind == EditKeep {
+			continue
+		}
+		lo, hi := max(0, i-context), min(len(script), i+context+1)
+		if n := len(hunks); n > 0 && lo <= hunks[n-1][1] {
+			hunks[n-1][1] = hi
+		} else {
+			hunks = append(hunks, [2]int{lo, hi})
+		}
+	}
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "--- %s\n+++ %s\n", from, to)
+	for _, h := range hunks {
+		hunk := script[h[0]:h[1]]
+
+		var aLen, bLen int
+		for _, e := range hunk {
+			if e.Kind != EditInsert {
+				aLen++
+			}
+			if e.Kind != EditDelete {
+				bLen++
+			}
+		}
+		fmt.Fprintf(bw, "@@ -%s +%s @@\n", hunkRange(hunk[0].A, aLen), hunkRange(hunk[0].B, bLen))
+
+		for _, e := range hunk {
+			prefix := " "
+			switch e.Kind {
+			case EditDelete:
+				prefix = "-"
+			case EditInsert:
+				prefix = "+"
+			}
+			fmt.Fprint(bw, prefix, fmt.Sprint(e.Value), "\n")
+		}
+	}
+	return bw.Flush()
+}
+
+// Unified returns the text WriteUnified would write for edits.
+func Unified[T any](edits iter.Seq[Edit[T]], opts *UnifiedOptions) string {
+	var sb strings.Builder
+	WriteUnified(&sb, edits, opts) // writing to a strings.Builder cannot fail
+	return sb.String()
+}
+
+// hunkRange formats the line range of a hunk, where start is the number of
+// lines before it. As in diff -u, lines are numbered from 1, a length of 1
+// is omitted, and an empty range is numbered by the line before it.
+func hunkRange(start, n int) string {
+	switch n {
+	case 0:
+		return strconv.Itoa(start) + ",0"
+	case 1:
+		return strconv.Itoa(start + 1)
+	}
+	return strconv.Itoa(start+1) + "," + strconv.Itoa(n)
+}
@@ -41,3 +41,44 @@ func UniqueFunc[T any](seq iter.Seq[T], equal func(a, b T) bool) iter.Seq[T] {
 		}
 	}
 }
+
+// Unique2 is the keyed companion to Unique; it yields each distinct
+// key/value pair from seq2 once, preserving first occurrence order.
+func Unique2[K, V comparable](seq2 iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		seen := make(map[Pair[K, V]]struct{})
+		for k, v := range seq2 {
+			p := Pair[K, V]{Key: k, Value: v}
+			if _, exists := seen[p]; exists {
+				continue
+			}
+			seen[p] = struct{}{}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// UniqueFunc2 behaves like Unique2 but determines equality of pairs with
+// equal, allowing use with non-comparable types.
+func UniqueFunc2[K, V any](seq2 iter.Seq2[K, V], equal func(k1 K, v1 V, k2 K, v2 V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var seen []Pair[K, V]
+		for k, v := range seq2 {
+			isUnique := true
+			for _, s := range seen {
+				if equal(k, v, s.Key, s.Value) {
+					isUnique = false
+					break
+				}
+			}
+			if isUnique {
+				seen = append(seen, Pair[K, V]{Key: k, Value: v})
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -94,3 +94,18 @@ func TestUniqueFunc(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestUnique2(t *testing.T) {
+	input := iters.Unpairs(slices.Values([]iters.Pair[string, int]{{"a", 1}, {"a", 2}, {"a", 1}, {"b", 1}}))
+
+	want := []iters.Pair[string, int]{{"a", 1}, {"a", 2}, {"b", 1}}
+	if got := slices.Collect(iters.Pairs(iters.Unique2(input))); !slices.Equal(got, want) {
+		t.Fatalf("Unique2: expected %v, got %v", want, got)
+	}
+
+	sameKey := func(k1 string, _ int, k2 string, _ int) bool { return k1 == k2 }
+	want = []iters.Pair[string, int]{{"a", 1}, {"b", 1}}
+	if got := slices.Collect(iters.Pairs(iters.UniqueFunc2(input, sameKey))); !slices.Equal(got, want) {
+		t.Fatalf("UniqueFunc2: expected %v, got %v", want, got)
+	}
+}
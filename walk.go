@@ -0,0 +1,165 @@
+package iters
+
+import (
+	"io/fs"
+	"iter"
+	"path"
+	"slices"
+)
+
+// WalkOptions configures Walk.
+type WalkOptions struct {
+	// MaxDepth limits how far below root the walk descends. Root has depth
+	// 0 and its direct children depth 1. Zero means no limit.
+	MaxDepth int
+
+	// FollowSymlinks causes symbolic links to directories to be walked as
+	// if they were directories. Links that would revisit a directory
+	// already being walked are reported but not followed. By default
+	// links are reported without being followed, as [fs.WalkDir] does.
+	FollowSymlinks bool
+
+	// SortFunc, if set, orders the entries of each directory. By default
+	// entries are visited in lexical order, as returned by [fs.ReadDir].
+	SortFunc func(a, b fs.DirEntry) int
+}
+
+// WalkEntry is a file or directory visited by Walk.
+type WalkEntry struct {
+	fs.DirEntry
+
+	// Path is the slash-separated path of the entry, including root, in
+	// the same form as the paths passed to fs.WalkDirFunc.
+	Path string
+
+	// Depth is the number of directories between root and the entry.
+	Depth int
+
+	skip *bool
+}
+
+// SkipDir prunes the walk so that the contents of the entry's directory
+// are not visited. It must be called before the loop body yielding the
+// entry returns, and has no effect on entries that are not directories.
+func (e WalkEntry) SkipDir() {
+	if e.skip != nil {
+		*e.skip = true
+	}
+}
+
+// Walk returns a sequence of the files and directories in the tree rooted
+// at root, visiting each directory before its contents. Breaking out of
+// the loop stops the walk, and calling SkipDir on a directory entry prunes
+// its contents.
+//
+// Errors are yielded alongside the entry they relate to: if root cannot be
+// read the error is yielded once and the sequence ends, otherwise a
+// directory that fails to read is yielded a second time with the error
+// and the walk continues with its siblings. A nil opts uses the defaults.
+func Walk(fsys fs.FS, root string, opts *WalkOptions) iter.Seq2[WalkEntry, error] {
+	return func(yield func(WalkEntry, error) bool) {
+		var o WalkOptions
+		if opts != nil {
+			o = *opts
+		}
+
+		info, err := fs.Stat(fsys, root)
+		if err != nil {
+			yield(WalkEntry{Path: root}, err)
+			return
+		}
+
+		w := walker{fsys: fsys, opts: o, yield: yield}
+		w.walk(fs.FileInfoToDirEntry(info), root, root, 0, nil)
+	}
+}
+
+// walker holds the state shared across a single Walk.
+type walker struct {
+	fsys  fs.FS
+	opts  WalkOptions
+	yield func(WalkEntry, error) bool
+}
+
+// walk visits entry at name, then its contents if it is a directory. real
+// is the link-free path of entry and ancestors holds the link-free paths of
+// the directories above it, which guards against symbolic link cycles. It
+// reports false once the consumer has stopped.
+func (w *walker) walk(entry fs.DirEntry, name, real string, depth int, ancestors []string) bool {
+	var skip bool
+	if !w.yield(WalkEntry{DirEntry: entry, Path: name, Depth: depth, skip: &skip}, nil) {
+		return false
+	}
+
+	if skip || (w.opts.MaxDepth > 0 && depth >= w.opts.MaxDepth) {
+		return true
+	}
+
+	switch {
+	case entry.IsDir():
+	case entry.Type()&fs.ModeSymlink != 0 && w.opts.FollowSymlinks:
+		target, ok := w.resolve(real)
+		if !ok || slices.Contains(ancestors, target) {
+			return true
+		}
+		real = target
+	default:
+		return true
+	}
+
+	entries, err := fs.ReadDir(w.fsys, name)
+	if err != nil {
+		if !w.yield(WalkEntry{DirEntry: entry, Path: name, Depth: depth}, err) {
+			return false
+		}
+		if len(entries) == 0 {
+			return true
+		}
+	}
+
+	if w.opts.SortFunc != nil {
+		slices.SortStableFunc(entries, w.opts.SortFunc)
+	}
+
+	ancestors = append(ancestors, real)
+	for _, child := range entries {
+		if !w.walk(child, path.Join(name, child.Name()), path.Join(real, child.Name()), depth+1, ancestors) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxLinkHops bounds how many symbolic links resolve follows in a chain.
+const maxLinkHops = 255
+
+// resolve follows the symbolic link at name until it reaches a directory,
+// returning the directory's link-free path. It reports false if the link
+// is broken, leaves fsys, or does not point at a directory.
+func (w *walker) resolve(name string) (string, bool) {
+	for range maxLinkHops {
+		target, err := fs.ReadLink(w.fsys, name)
+		if err != nil {
+			return "", false
+		}
+		if !path.IsAbs(target) {
+			target = path.Join(path.Dir(name), target)
+		}
+		if !fs.ValidPath(target) {
+			return "", false
+		}
+
+		info, err := fs.Lstat(w.fsys, target)
+		if err != nil {
+			return "", false
+		}
+		if info.IsDir() {
+			return target, true
+		}
+		if info.Mode()&fs.ModeSymlink == 0 {
+			return "", false
+		}
+		name = target
+	}
+	return "", false
+}
@@ -0,0 +1,263 @@
+package iters_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/picatz/iters"
+)
+
+func ExampleWalk() {
+	fsys := fstest.MapFS{
+		"docs/intro.md":      {},
+		"docs/guide.md":      {},
+		"src/main.go":        {},
+		"src/vendor/dep.go":  {},
+		"src/util/helper.go": {},
+	}
+
+	for entry, err := range iters.Walk(fsys, ".", nil) {
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if entry.IsDir() && entry.Name() == "vendor" {
+			entry.SkipDir()
+			continue
+		}
+		if strings.HasSuffix(entry.Path, ".go") {
+			fmt.Println(entry.Path)
+		}
+	}
+	// Output:
+	// src/main.go
+	// src/util/helper.go
+}
+
+var walkFS = fstest.MapFS{
+	"a/1.txt":   {},
+	"a/b/2.txt": {},
+	"a/b/c/3":   {},
+	"d/4.txt":   {},
+	"e.txt":     {},
+}
+
+// walkPaths collects the paths produced by Walk, failing on any error.
+func walkPaths(t *testing.T, fsys fs.FS, root string, opts *iters.WalkOptions) []string {
+	t.Helper()
+
+	var paths []string
+	for entry, err := range iters.Walk(fsys, root, opts) {
+		if err != nil {
+			t.Fatalf("Walk: unexpected error %v", err)
+		}
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+type walkTableTest struct {
+	name     string
+	fsys     fs.FS
+	root     string
+	opts     *iters.WalkOptions
+	expected []string
+}
+
+func (test walkTableTest) Run(t *testing.T) {
+	t.Run(test.name, func(t *testing.T) {
+		got := walkPaths(t, test.fsys, test.root, test.opts)
+		if !slices.Equal(got, test.expected) {
+			t.Fatalf("Walk: expected %q, got %q", test.expected, got)
+		}
+	})
+}
+
+func TestWalk(t *testing.T) {
+	tests := []runnableTest{
+		walkTableTest{
+			name:     "whole tree",
+			fsys:     walkFS,
+			root:     ".",
+			expected: []string{".", "a", "a/1.txt", "a/b", "a/b/2.txt", "a/b/c", "a/b/c/3", "d", "d/4.txt", "e.txt"},
+		},
+		walkTableTest{
+			name:     "subdirectory",
+			fsys:     walkFS,
+			root:     "a/b",
+			expected: []string{"a/b", "a/b/2.txt", "a/b/c", "a/b/c/3"},
+		},
+		walkTableTest{
+			name:     "single file",
+			fsys:     walkFS,
+			root:     "e.txt",
+			expected: []string{"e.txt"},
+		},
+		walkTableTest{
+			name:     "max depth",
+			fsys:     walkFS,
+			root:     ".",
+			opts:     &iters.WalkOptions{MaxDepth: 1},
+			expected: []string{".", "a", "d", "e.txt"},
+		},
+		walkTableTest{
+			name: "custom ordering",
+			fsys: walkFS,
+			root: ".",
+			opts: &iters.WalkOptions{
+				MaxDepth: 1,
+				SortFunc: func(a, b fs.DirEntry) int {
+					return strings.Compare(b.Name(), a.Name())
+				},
+			},
+			expected: []string{".", "e.txt", "d", "a"},
+		},
+	}
+
+	for _, test := range tests {
+		test.Run(t)
+	}
+}
+
+func TestWalkDepth(t *testing.T) {
+	for entry, err := range iters.Walk(walkFS, "a", nil) {
+		if err != nil {
+			t.Fatalf("Walk: unexpected error %v", err)
+		}
+		if want := strings.Count(entry.Path, "/"); entry.Depth != want {
+			t.Fatalf("expected depth %d for %q, got %d", want, entry.Path, entry.Depth)
+		}
+	}
+}
+
+func TestWalkSkipDir(t *testing.T) {
+	var got []string
+	for entry, err := range iters.Walk(walkFS, ".", nil) {
+		if err != nil {
+			t.Fatalf("Walk: unexpected error %v", err)
+		}
+		got = append(got, entry.Path)
+		if entry.Path == "a/b" || entry.Path == "e.txt" {
+			entry.SkipDir()
+		}
+	}
+
+	want := []string{".", "a", "a/1.txt", "a/b", "d", "d/4.txt", "e.txt"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+// countingFS counts the directories read through it.
+type countingFS struct {
+	fs.FS
+	reads int
+}
+
+func (c *countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	c.reads++
+	return fs.ReadDir(c.FS, name)
+}
+
+func TestWalkComposesWithLimit(t *testing.T) {
+	fsys := &countingFS{FS: walkFS}
+
+	files := iters.Limit(
+		iters.Filter(
+			iters.UntilErr(iters.Walk(fsys, ".", nil)),
+			func(entry iters.WalkEntry) bool { return !entry.IsDir() },
+		),
+		1,
+	)
+
+	var got []string
+	for entry := range files {
+		got = append(got, entry.Path)
+	}
+
+	if want := []string{"a/1.txt"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if fsys.reads >= 5 {
+		t.Fatalf("expected the walk to stop before reading every directory, read %d", fsys.reads)
+	}
+}
+
+func TestWalkSymlinks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"real/file.txt": {},
+		"link":          {Data: []byte("real"), Mode: fs.ModeSymlink},
+		"real/loop":     {Data: []byte(".."), Mode: fs.ModeSymlink},
+		"broken":        {Data: []byte("missing"), Mode: fs.ModeSymlink},
+	}
+
+	got := walkPaths(t, fsys, ".", nil)
+	want := []string{".", "broken", "link", "real", "real/file.txt", "real/loop"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("without following: expected %q, got %q", want, got)
+	}
+
+	got = walkPaths(t, fsys, ".", &iters.WalkOptions{FollowSymlinks: true})
+	want = []string{
+		".", "broken",
+		"link", "link/file.txt", "link/loop",
+		"real", "real/file.txt", "real/loop",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("following: expected %q, got %q", want, got)
+	}
+}
+
+func TestWalkErrors(t *testing.T) {
+	var errs []error
+	for _, err := range iters.Walk(walkFS, "missing", nil) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], fs.ErrNotExist) {
+		t.Fatalf("expected a single fs.ErrNotExist, got %v", errs)
+	}
+
+	boom := errors.New("boom")
+	fsys := failingDirFS{FS: walkFS, dir: "a/b", err: boom}
+
+	var (
+		paths  []string
+		failed []string
+	)
+	for entry, err := range iters.Walk(fsys, ".", nil) {
+		if err != nil {
+			if !errors.Is(err, boom) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			failed = append(failed, entry.Path)
+			continue
+		}
+		paths = append(paths, entry.Path)
+	}
+
+	if want := []string{"a/b"}; !slices.Equal(failed, want) {
+		t.Fatalf("expected errors for %q, got %q", want, failed)
+	}
+	if want := []string{".", "a", "a/1.txt", "a/b", "d", "d/4.txt", "e.txt"}; !slices.Equal(paths, want) {
+		t.Fatalf("expected %q, got %q", want, paths)
+	}
+}
+
+// failingDirFS fails to read a single directory.
+type failingDirFS struct {
+	fs.FS
+	dir string
+	err error
+}
+
+func (f failingDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name == f.dir {
+		return nil, f.err
+	}
+	return fs.ReadDir(f.FS, name)
+}
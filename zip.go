@@ -26,3 +26,15 @@ func Zip[T1, T2 any](seq1 iter.Seq[T1], seq2 iter.Seq[T2]) iter.Seq2[T1, T2] {
 		}
 	}
 }
+
+// Zip2 is the keyed companion to Zip. It pairs the key/value pairs of two
+// sequences in order, yielding each as a [Pair], and stops when either
+// sequence ends. Both inputs are read through [Reusable2], so each is read
+// at most once, however many times the result is iterated, and the result
+// can be iterated repeatedly even when the inputs are single-use. As with
+// Reusable2, the pairs read so far are kept in memory.
+func Zip2[K1, V1, K2, V2 any](seq1 iter.Seq2[K1, V1], seq2 iter.Seq2[K2, V2]) iter.Seq2[Pair[K1, V1], Pair[K2, V2]] {
+	pairs1 := Pairs(Reusable2(seq1))
+	pairs2 := Pairs(Reusable2(seq2))
+	return Zip(pairs1, pairs2)
+}
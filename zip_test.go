@@ -2,10 +2,13 @@ package iters_test
 
 import (
 	"fmt"
+	"maps"
+	"runtime"
 	"slices"
 	"testing"
 
 	"github.com/picatz/iters"
+	"github.com/picatz/iters/iterstest"
 )
 
 func ExampleZip_sequences() {
@@ -68,3 +71,58 @@ func TestZip(t *testing.T) {
 		test.Run(t)
 	}
 }
+
+func TestZip2(t *testing.T) {
+	letters, c := iterstest.Counting2(slices.All([]string{"a", "b", "c"}))
+	numbers := maps.All(map[string]int{"one": 1})
+
+	zipped := iters.Zip2(letters, numbers)
+	for range 2 {
+		var got []string
+		for l, n := range zipped {
+			got = append(got, fmt.Sprintf("%v %v %v %v", l.Key, l.Value, n.Key, n.Value))
+		}
+		if !slices.Equal(got, []string{"0 a one 1"}) {
+			t.Fatalf("Zip2: expected [0 a one 1], got %v", got)
+		}
+	}
+	if c.Iterations() != 1 {
+		t.Fatalf("Zip2: expected each input to be read once, got %d iterations", c.Iterations())
+	}
+
+	iterstest.CheckSeq2(t, iters.Zip2(slices.All([]int{1, 2, 3}), slices.All([]int{4, 5, 6})))
+}
+
+func TestZip2UnequalLengthsDoNotLeak(t *testing.T) {
+	iterstest.VerifyNoLeaks(t)
+
+	for range 10 {
+		for range iters.Zip2(slices.All([]int{1, 2}), iters.Enumerate(iterstest.Naturals())) {
+		}
+		for range iters.Zip2(iters.Enumerate(iterstest.Naturals()), slices.All([]int{1, 2})) {
+		}
+	}
+	// The longer input stays suspended until the result is unreachable.
+	runtime.GC()
+}
+
+func TestZip2SingleUseInputs(t *testing.T) {
+	zipped := iters.Zip2(iters.Enumerate(chanSeq(5)), iters.Enumerate(chanSeq(5)))
+	for a := range zipped {
+		if a.Key == 1 {
+			break
+		}
+	}
+	for range 2 {
+		var values []int
+		for a, b := range zipped {
+			if a.Value != b.Value {
+				t.Fatalf("Zip2: expected matching pairs, got %v and %v", a, b)
+			}
+			values = append(values, a.Value)
+		}
+		if !slices.Equal(values, []int{0, 1, 2, 3, 4}) {
+			t.Fatalf("Zip2: expected values [0 1 2 3 4], got %v", values)
+		}
+	}
+}